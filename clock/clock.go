@@ -0,0 +1,33 @@
+package clock
+
+import "time"
+
+// Clock menyediakan waktu saat ini; diinjeksikan ke job terjadwal agar waktunya bisa dikendalikan
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+// System mengembalikan clock yang memakai waktu sistem
+func System() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed adalah clock dengan waktu yang bisa diatur manual
+type Fixed struct {
+	At time.Time
+}
+
+func (f *Fixed) Now() time.Time {
+	return f.At
+}
+
+// Advance memajukan waktu clock sebesar d
+func (f *Fixed) Advance(d time.Duration) {
+	f.At = f.At.Add(d)
+}
@@ -39,17 +39,55 @@ func DBInit() *gorm.DB {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	if err := Migrate(db); err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	return db
+}
+
+// Migrate membuat atau memperbarui tabel lalu menjalankan migrasi data yang belum diterapkan
+func Migrate(db *gorm.DB) error {
 	// Automigrate tables
-	err = db.AutoMigrate(
+	err := db.AutoMigrate(
 		&entity.Event{},
 		&entity.Ticket{},
+		&entity.TicketItem{},
+		&entity.TicketType{},
+		&entity.Reservation{},
+		&entity.Refund{},
+		&entity.TicketTransfer{},
+		&entity.WaitlistEntry{},
+		&entity.EventCancellation{},
+		&entity.Notification{},
+		&entity.Venue{},
+		&entity.Section{},
+		&entity.SeatRow{},
+		&entity.Seat{},
+		&entity.EventSeat{},
+		&entity.EventSeries{},
+		&entity.EventSeriesException{},
+		&entity.Order{},
+		&entity.CartItem{},
+		&entity.Invoice{},
+		&entity.InvoiceLine{},
+		&entity.InvoiceSequence{},
+		&entity.PromoCode{},
+		&entity.PromoRedemption{},
+		&entity.PricingRule{},
 		&entity.User{},
 		&entity.TokenBlacklist{},
+		&entity.SchemaMigration{},
+		&entity.SchedulerLock{},
 		// Add other entities here
 	)
 	if err != nil {
-		log.Fatalf("Failed to automigrate database: %v", err)
+		return fmt.Errorf("automigrate: %w", err)
 	}
 
-	return db
+	// Migrasi data
+	if err := runDataMigrations(db); err != nil {
+		return fmt.Errorf("data migrations: %w", err)
+	}
+	return nil
 }
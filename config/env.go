@@ -0,0 +1,72 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetEnvDuration membaca durasi (mis. "15m") dari environment, atau fallback jika kosong/tidak valid
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid duration for %s: %q, using default %s\n", key, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+// GetEnvFloat membaca angka desimal (mis. "11" atau "2500.50") dari environment, atau fallback jika kosong/tidak valid
+func GetEnvFloat(key string, fallback float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		log.Printf("Invalid number for %s: %q, using default %v\n", key, value, fallback)
+		return fallback
+	}
+	return number
+}
+
+// GetEnvBool membaca flag true/false dari environment, atau fallback jika kosong/tidak valid
+func GetEnvBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid flag for %s: %q, using default %v\n", key, value, fallback)
+		return fallback
+	}
+	return flag
+}
+
+// GetEnv membaca nilai dari environment, atau fallback jika kosong
+func GetEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// DefaultCurrency membaca kode mata uang ISO 4217 untuk harga tanpa mata uang dan data lama (default IDR)
+func DefaultCurrency() string {
+	currency := strings.ToUpper(strings.TrimSpace(GetEnv("PAYMENT_CURRENCY", "IDR")))
+	if len(currency) != 3 {
+		log.Printf("Invalid currency for PAYMENT_CURRENCY: %q, using default IDR\n", currency)
+		return "IDR"
+	}
+	return currency
+}
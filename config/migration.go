@@ -0,0 +1,232 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"eventix/entity"
+	"eventix/money"
+	"eventix/ticketcode"
+	"gorm.io/gorm"
+)
+
+type dataMigration struct {
+	ID string
+	Up func(tx *gorm.DB) error
+}
+
+// Migrasi data dijalankan berurutan setelah AutoMigrate, masing-masing hanya sekali
+var dataMigrations = []dataMigration{
+	{
+		// Kapasitas dulu dikurangi setiap kali tiket dibeli; pindahkan jumlah kursi terjual ke kolom sold_count
+		// dan kembalikan kapasitas awal. Pengurangan yang mencapai nol dulu tidak tersimpan (Updates melewati
+		// nilai nol), sehingga sisa kapasitas yang tidak lebih besar dari pembelian terbesar tidak bisa dipercaya;
+		// event seperti itu dianggap habis terjual dan kapasitasnya bisa dinaikkan admin lewat update event.
+		ID: "20261018_event_sold_count",
+		Up: func(tx *gorm.DB) error {
+			type soldEvent struct {
+				ID          uint
+				Capacity    int
+				Sold        int
+				MaxQuantity int
+			}
+			var events []soldEvent
+			err := tx.Table("events e").
+				Select("e.id, e.capacity, SUM(t.quantity) AS sold, MAX(t.quantity) AS max_quantity").
+				Joins("JOIN tickets t ON t.event_id = e.id AND t.status = 'purchased'").
+				Group("e.id, e.capacity").
+				Scan(&events).Error
+			if err != nil {
+				return err
+			}
+
+			for _, event := range events {
+				capacity := event.Capacity + event.Sold
+				if event.Capacity <= event.MaxQuantity {
+					capacity = event.Sold
+				}
+				if err := tx.Table("events").Where("id = ?", event.ID).
+					Updates(map[string]interface{}{"sold_count": event.Sold, "capacity": capacity}).Error; err != nil {
+					return err
+				}
+				log.Printf("Event %d: sold_count %d, capacity %d -> %d\n", event.ID, event.Sold, event.Capacity, capacity)
+			}
+			return nil
+		},
+	},
+	{
+		// Tiket lama belum memiliki kode verifikasi
+		ID: "20261018_ticket_code",
+		Up: func(tx *gorm.DB) error {
+			var ids []uint
+			if err := tx.Model(&entity.Ticket{}).Where("code IS NULL OR code = ''").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			for _, id := range ids {
+				code, err := ticketcode.NewCode()
+				if err != nil {
+					return err
+				}
+				if err := tx.Model(&entity.Ticket{}).Where("id = ?", id).Update("code", code).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Tiket lama belum memiliki item per kursi; status check-in tingkat tiket dipindah ke item
+		ID: "20261018_ticket_items",
+		Up: func(tx *gorm.DB) error {
+			hasCheckIn := tx.Migrator().HasColumn(&entity.Ticket{}, "checked_in_at")
+
+			type legacyTicket struct {
+				ID          uint
+				EventID     uint
+				Quantity    int
+				Status      string
+				CheckedInAt *time.Time
+				CheckedInBy *uint
+				GateID      string
+			}
+			columns := "id, event_id, quantity, status"
+			if hasCheckIn {
+				columns += ", checked_in_at, checked_in_by, gate_id"
+			}
+			var tickets []legacyTicket
+			err := tx.Table("tickets").Select(columns).
+				Where("NOT EXISTS (SELECT 1 FROM ticket_items WHERE ticket_items.ticket_id = tickets.id)").
+				Scan(&tickets).Error
+			if err != nil {
+				return err
+			}
+
+			for _, ticket := range tickets {
+				status := "active"
+				if ticket.Status == "cancelled" {
+					status = "cancelled"
+				}
+				for i := 0; i < ticket.Quantity; i++ {
+					code, err := ticketcode.NewCode()
+					if err != nil {
+						return err
+					}
+					item := entity.TicketItem{
+						TicketID:    ticket.ID,
+						EventID:     ticket.EventID,
+						Code:        code,
+						Status:      status,
+						CheckedInAt: ticket.CheckedInAt,
+						CheckedInBy: ticket.CheckedInBy,
+						GateID:      ticket.GateID,
+					}
+					if err := tx.Create(&item).Error; err != nil {
+						return err
+					}
+				}
+			}
+
+			if hasCheckIn {
+				for _, column := range []string{"checked_in_at", "checked_in_by", "gate_id"} {
+					if err := tx.Migrator().DropColumn(&entity.Ticket{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Nilai uang dulu disimpan sebagai float dalam satuan utama tanpa mata uang; pindahkan ke
+		// kolom satuan terkecil dengan mata uang default. Pembulatan ROUND pada DECIMAL di MySQL
+		// menjauhi nol untuk nilai tepat di tengah, sama seperti package money.
+		ID: "20261018_money_minor_units",
+		Up: func(tx *gorm.DB) error {
+			currency := DefaultCurrency()
+			scale := 1
+			for i := 0; i < money.Exponent(currency); i++ {
+				scale *= 10
+			}
+
+			columns := []struct {
+				Model  interface{}
+				Table  string
+				Old    string
+				Prefix string
+			}{
+				{&entity.Event{}, "events", "price", "price_"},
+				{&entity.EventSeries{}, "event_series", "price", "price_"},
+				{&entity.TicketType{}, "ticket_types", "price", "price_"},
+				{&entity.Ticket{}, "tickets", "price", "price_"},
+				{&entity.Ticket{}, "tickets", "discount", "discount_"},
+				{&entity.Order{}, "orders", "subtotal", "subtotal_"},
+				{&entity.Order{}, "orders", "discount", "discount_"},
+				{&entity.Order{}, "orders", "service_fee", "service_fee_"},
+				{&entity.Order{}, "orders", "tax", "tax_"},
+				{&entity.Order{}, "orders", "total", "total_"},
+				{&entity.Refund{}, "refunds", "amount", "refund_"},
+				{&entity.EventCancellation{}, "event_cancellations", "refunded_amount", "refunded_total_"},
+				{&entity.PromoRedemption{}, "promo_redemptions", "discount", "discount_"},
+			}
+			for _, c := range columns {
+				if !tx.Migrator().HasColumn(c.Model, c.Old) {
+					continue
+				}
+				err := tx.Exec(fmt.Sprintf("UPDATE %s SET %samount = ROUND(CAST(COALESCE(%s, 0) AS DECIMAL(20,6)) * ?), %scurrency = ?",
+					c.Table, c.Prefix, c.Old, c.Prefix), scale, currency).Error
+				if err != nil {
+					return err
+				}
+				if err := tx.Migrator().DropColumn(c.Model, c.Old); err != nil {
+					return err
+				}
+			}
+
+			// Kolom value tetap dipakai untuk persentase; nominal pindah ke kolom uang
+			err := tx.Exec("UPDATE promo_codes SET discount_amount = ROUND(CAST(value AS DECIMAL(20,6)) * ?), discount_currency = ?, value = 0 WHERE discount_type = 'fixed'",
+				scale, currency).Error
+			if err != nil {
+				return err
+			}
+			return tx.Exec("UPDATE pricing_rules SET adjustment_amount = ROUND(CAST(value AS DECIMAL(20,6)) * ?), adjustment_currency = ?, value = 0 WHERE adjustment_type IN ('amount', 'price')",
+				scale, currency).Error
+		},
+	},
+	{
+		// Order lama yang masih menunggu pembayaran belum memiliki batas waktu; beri waktu sejak migrasi
+		ID: "20261018_order_payment_due",
+		Up: func(tx *gorm.DB) error {
+			dueAt := time.Now().Add(GetEnvDuration("ORDER_PAYMENT_TTL", 30*time.Minute))
+			return tx.Model(&entity.Order{}).
+				Where("status = ? AND payment_due_at IS NULL", "pending_payment").
+				Update("payment_due_at", dueAt).Error
+		},
+	},
+}
+
+func runDataMigrations(db *gorm.DB) error {
+	for _, m := range dataMigrations {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var applied entity.SchemaMigration
+			err := tx.First(&applied, "id = ?", m.ID).Error
+			if err == nil {
+				return nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			log.Printf("Applied data migration %s\n", m.ID)
+			return tx.Create(&entity.SchemaMigration{ID: m.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"errors"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CheckInController struct {
+	service service.CheckInService
+}
+
+func NewCheckInController(checkInService service.CheckInService) *CheckInController {
+	return &CheckInController{
+		service: checkInService,
+	}
+}
+
+// CheckIn godoc
+// @Summary Check in a ticket
+// @Description Staff scan a ticket QR payload (or type its code) at a gate of a specific event
+// @Tags Check-in
+// @Accept json
+// @Produce json
+// @Param request body map[string]interface{} true "event_id, gate_id and either payload or code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Router /checkin [post]
+func (ctrl *CheckInController) CheckIn(c *gin.Context) {
+	staffID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	var reqBody struct {
+		EventID uint   `json:"event_id" binding:"required"`
+		GateID  string `json:"gate_id" binding:"required"`
+		Payload string `json:"payload"`
+		Code    string `json:"code"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "code": "INVALID_REQUEST", "message": "Invalid check-in data", "data": nil})
+		return
+	}
+
+	items, err := ctrl.service.CheckIn(reqBody.Code, reqBody.Payload, reqBody.EventID, reqBody.GateID, staffID.(uint))
+	if err != nil {
+		status, code := checkInErrorCode(err)
+		c.JSON(status, gin.H{"status": "error", "code": code, "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "code": "CHECKED_IN", "message": "Ticket checked in successfully", "data": items})
+}
+
+// GetCheckInStats godoc
+// @Summary Get live check-in counts
+// @Description Retrieve the number of checked-in tickets and seats per gate for an event (Admin only)
+// @Tags Check-in
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/events/{id}/checkins [get]
+func (ctrl *CheckInController) GetCheckInStats(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	stats, err := ctrl.service.GetCheckInStats(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Check-in stats retrieved successfully", "data": stats})
+}
+
+// checkInErrorCode memetakan error check-in ke HTTP status dan kode error yang bisa dibaca aplikasi scanner
+func checkInErrorCode(err error) (int, string) {
+	switch {
+	case errors.Is(err, service.ErrCheckInInvalidPayload):
+		return http.StatusBadRequest, "INVALID_PAYLOAD"
+	case errors.Is(err, service.ErrCheckInTicketNotFound):
+		return http.StatusNotFound, "TICKET_NOT_FOUND"
+	case errors.Is(err, service.ErrCheckInWrongEvent):
+		return http.StatusUnprocessableEntity, "WRONG_EVENT"
+	case errors.Is(err, service.ErrCheckInTicketCancelled):
+		return http.StatusUnprocessableEntity, "TICKET_CANCELLED"
+	case errors.Is(err, service.ErrCheckInTicketNotPaid):
+		return http.StatusUnprocessableEntity, "TICKET_NOT_PAID"
+	case errors.Is(err, service.ErrCheckInDuplicate):
+		return http.StatusConflict, "ALREADY_CHECKED_IN"
+	default:
+		return http.StatusBadRequest, "CHECKIN_FAILED"
+	}
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EventCancellationController struct {
+	service service.EventCancellationService
+}
+
+func NewEventCancellationController(cancellationService service.EventCancellationService) *EventCancellationController {
+	return &EventCancellationController{
+		service: cancellationService,
+	}
+}
+
+// CancelEvent godoc
+// @Summary Cancel an event
+// @Description Mark an event as cancelled and cancel every ticket in the background with a full refund; buyers are notified (Admin only)
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param request body map[string]string false "Cancellation reason"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/events/{id}/cancel [post]
+func (ctrl *EventCancellationController) CancelEvent(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	// Alasan pembatalan bersifat opsional
+	var reqBody struct {
+		Reason string `json:"reason"`
+	}
+	_ = c.ShouldBindJSON(&reqBody)
+
+	cancellation, err := ctrl.service.CancelEvent(uint(id), adminID.(uint), reqBody.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "success", "message": "Event cancellation started", "data": cancellation})
+}
+
+// GetCancellationProgress godoc
+// @Summary Get event cancellation progress
+// @Description Retrieve how many tickets have been cancelled and refunded so far (Admin only)
+// @Tags Events
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/events/{id}/cancel [get]
+func (ctrl *EventCancellationController) GetCancellationProgress(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	cancellation, err := ctrl.service.GetCancellationProgress(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Cancellation progress retrieved successfully", "data": cancellation})
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EventSeriesController struct {
+	service service.EventSeriesService
+}
+
+func NewEventSeriesController(seriesService service.EventSeriesService) *EventSeriesController {
+	return &EventSeriesController{
+		service: seriesService,
+	}
+}
+
+// GetAllSeries godoc
+// @Summary Get all event series
+// @Description Retrieve every recurring event series (Admin only)
+// @Tags Event Series
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/series [get]
+func (ctrl *EventSeriesController) GetAllSeries(c *gin.Context) {
+	series, err := ctrl.service.GetAllSeries()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Event series retrieved successfully", "data": series})
+}
+
+// GetSeriesByID godoc
+// @Summary Get an event series
+// @Description Retrieve an event series with its materialized occurrences and exceptions (Admin only)
+// @Tags Event Series
+// @Produce json
+// @Param id path uint true "Series ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/series/{id} [get]
+func (ctrl *EventSeriesController) GetSeriesByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid series ID", "data": nil})
+		return
+	}
+
+	series, err := ctrl.service.GetSeriesByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Event series retrieved successfully", "data": series})
+}
+
+// CreateSeries godoc
+// @Summary Create an event series
+// @Description Create a recurring event from an RFC 5545 recurrence rule (FREQ=DAILY|WEEKLY|MONTHLY|YEARLY with INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY); occurrences are created as regular events up to the materialization horizon (Admin only)
+// @Tags Event Series
+// @Accept json
+// @Produce json
+// @Param series body entity.EventSeries true "Series details"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/series [post]
+func (ctrl *EventSeriesController) CreateSeries(c *gin.Context) {
+	var series entity.EventSeries
+	if err := c.ShouldBindJSON(&series); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid series data", "data": nil})
+		return
+	}
+
+	createdSeries, err := ctrl.service.CreateSeries(series)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Event series created successfully", "data": createdSeries})
+}
+
+// UpdateSeries godoc
+// @Summary Update an event series template
+// @Description Replace the series template (description, capacity, price, status, image and policies) and apply it to upcoming occurrences that have not been edited individually. The name, rule and schedule cannot be changed (Admin only)
+// @Tags Event Series
+// @Accept json
+// @Produce json
+// @Param id path uint true "Series ID"
+// @Param series body entity.EventSeries true "Series template"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/series/{id} [put]
+func (ctrl *EventSeriesController) UpdateSeries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid series ID", "data": nil})
+		return
+	}
+
+	var series entity.EventSeries
+	if err := c.ShouldBindJSON(&series); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid series data", "data": nil})
+		return
+	}
+	series.ID = uint(id)
+
+	updatedSeries, err := ctrl.service.UpdateSeries(series)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Event series updated successfully", "data": updatedSeries})
+}
+
+// AddException godoc
+// @Summary Exclude an occurrence from a series
+// @Description Skip one occurrence of the series; an already created occurrence is deleted if no tickets have been sold (Admin only)
+// @Tags Event Series
+// @Accept json
+// @Produce json
+// @Param id path uint true "Series ID"
+// @Param exception body entity.EventSeriesException true "Occurrence start time and reason"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/series/{id}/exceptions [post]
+func (ctrl *EventSeriesController) AddException(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid series ID", "data": nil})
+		return
+	}
+
+	var exception entity.EventSeriesException
+	if err := c.ShouldBindJSON(&exception); err != nil || exception.OccurrenceStart.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid exception data", "data": nil})
+		return
+	}
+
+	createdException, err := ctrl.service.AddException(uint(id), exception.OccurrenceStart, exception.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Occurrence excluded successfully", "data": createdException})
+}
+
+// RemoveException godoc
+// @Summary Remove a series exception
+// @Description Include a previously excluded occurrence again; a deleted occurrence is restored (Admin only)
+// @Tags Event Series
+// @Produce json
+// @Param id path uint true "Series ID"
+// @Param exception_id path uint true "Exception ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/series/{id}/exceptions/{exception_id} [delete]
+func (ctrl *EventSeriesController) RemoveException(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid series ID", "data": nil})
+		return
+	}
+	exceptionID, err := strconv.ParseUint(c.Param("exception_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid exception ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.RemoveException(uint(id), uint(exceptionID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	// Kemunculan yang belum pernah dibuat langsung dibuat tanpa menunggu scheduler
+	if _, err := ctrl.service.MaterializeSeries(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Exception removed successfully", "data": nil})
+}
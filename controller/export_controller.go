@@ -1,6 +1,7 @@
 package controller
 
 import (
+    "eventix/money"
     "eventix/service"
     "encoding/csv"
     "net/http"
@@ -34,14 +35,7 @@ func (ctrl *ExportController) ExportSummaryReport(c *gin.Context) {
     writer := csv.NewWriter(c.Writer)
     defer writer.Flush()
 
-    // Header CSV
-    writer.Write([]string{"Total Tickets Sold", "Total Revenue"})
-
-    // Data CSV
-    writer.Write([]string{
-        strconv.FormatInt(report["total_items"].(int64), 10),
-        strconv.FormatFloat(report["total_revenue"].(float64), 'f', 2, 64),
-    })
+    writeRevenueCSV(writer, report)
 }
 
 func (ctrl *ExportController) ExportEventReport(c *gin.Context) {
@@ -67,12 +61,22 @@ func (ctrl *ExportController) ExportEventReport(c *gin.Context) {
     writer := csv.NewWriter(c.Writer)
     defer writer.Flush()
 
+    writeRevenueCSV(writer, report)
+}
+
+// writeRevenueCSV menulis satu baris per mata uang karena pendapatan beda mata uang tidak dijumlahkan
+func writeRevenueCSV(writer *csv.Writer, report map[string]interface{}) {
     // Header CSV
-    writer.Write([]string{"Total Tickets Sold", "Total Revenue"})
+    writer.Write([]string{"Total Tickets Sold", "Currency", "Total Revenue"})
 
     // Data CSV
-    writer.Write([]string{
-        strconv.FormatInt(report["total_items"].(int64), 10),
-        strconv.FormatFloat(report["total_revenue"].(float64), 'f', 2, 64),
-    })
+    totalItems := strconv.FormatInt(report["total_items"].(int64), 10)
+    revenue, _ := report["total_revenue"].([]money.Money)
+    if len(revenue) == 0 {
+        writer.Write([]string{totalItems, money.DefaultCurrency, money.Zero(money.DefaultCurrency).Decimal()})
+        return
+    }
+    for _, total := range revenue {
+        writer.Write([]string{totalItems, total.Currency, total.Decimal()})
+    }
 }
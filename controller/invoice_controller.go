@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"eventix/service"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceController struct {
+	service service.InvoiceService
+}
+
+func NewInvoiceController(invoiceService service.InvoiceService) *InvoiceController {
+	return &InvoiceController{
+		service: invoiceService,
+	}
+}
+
+// GetTicketReceipt godoc
+// @Summary Download ticket receipt
+// @Description Render the invoice of the ticket's paid order as a PDF receipt, followed by an e-ticket page with a QR code for every active seat. Holders who did not buy the order (e.g. transfer recipients) receive the e-ticket pages only
+// @Tags Tickets
+// @Produce application/pdf
+// @Param id path uint true "Ticket ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id}/receipt.pdf [get]
+func (ctrl *InvoiceController) GetTicketReceipt(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
+		return
+	}
+
+	pdf, err := ctrl.service.GetTicketReceipt(uint(id), userID.(uint), role)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=receipt-%d.pdf", id))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
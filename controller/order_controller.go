@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderController struct {
+	service service.OrderService
+}
+
+func NewOrderController(orderService service.OrderService) *OrderController {
+	return &OrderController{
+		service: orderService,
+	}
+}
+
+// GetCart godoc
+// @Summary Get the shopping cart
+// @Description Retrieve the logged-in user's cart with current prices, availability and estimated order totals (service fee and tax)
+// @Tags Orders
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /cart [get]
+func (ctrl *OrderController) GetCart(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	cart, err := ctrl.service.GetCart(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to retrieve cart", "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Cart retrieved successfully", "data": cart})
+}
+
+// AddCartItem godoc
+// @Summary Add tickets to the cart
+// @Description Add tickets for an event (and ticket type) to the cart; adding the same event and ticket type again increases the quantity
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param item body entity.CartItem true "Cart item (event_id, ticket_type_id, quantity)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /cart/items [post]
+func (ctrl *OrderController) AddCartItem(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	var item entity.CartItem
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid cart item data", "data": nil})
+		return
+	}
+
+	// User diambil dari sesi login
+	item.UserID = userID.(uint)
+
+	savedItem, err := ctrl.service.AddCartItem(item)
+	if err != nil {
+		status, code := purchaseErrorCode(err)
+		c.JSON(status, gin.H{"status": "error", "code": code, "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Item added to cart successfully", "data": savedItem})
+}
+
+// RemoveCartItem godoc
+// @Summary Remove an item from the cart
+// @Description Remove one line from the logged-in user's cart
+// @Tags Orders
+// @Produce json
+// @Param id path uint true "Cart item ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /cart/items/{id} [delete]
+func (ctrl *OrderController) RemoveCartItem(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid cart item ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.RemoveCartItem(userID.(uint), uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Item removed from cart successfully", "data": nil})
+}
+
+// Checkout godoc
+// @Summary Check out the cart
+// @Description Create an order with one ticket per cart line, atomically (all or none), and start a single payment for the order total. An optional promo code discounts the eligible lines and counts as one use
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param body body object false "Optional promo code, e.g. {\"promo_code\": \"EARLY10\"}"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Router /checkout [post]
+func (ctrl *OrderController) Checkout(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	// Kode promo bersifat opsional
+	var reqBody struct {
+		PromoCode string `json:"promo_code"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid data", "data": nil})
+			return
+		}
+	}
+
+	order, err := ctrl.service.Checkout(userID.(uint), reqBody.PromoCode)
+	if err != nil {
+		status, code := purchaseErrorCode(err)
+		c.JSON(status, gin.H{"status": "error", "code": code, "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Order created successfully", "data": order})
+}
+
+// GetOrders godoc
+// @Summary Get order history
+// @Description Retrieve the logged-in user's orders with their tickets, newest first
+// @Tags Orders
+// @Produce json
+// @Param page query int false "Page number"
+// @Param size query int false "Page size"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /orders [get]
+func (ctrl *OrderController) GetOrders(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	// Ambil parameter pagination dari query string
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+
+	orders, totalItems, err := ctrl.service.GetOrdersByUserID(userID.(uint), page, size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to retrieve orders", "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Orders retrieved successfully",
+		"data":    orders,
+		"meta": map[string]interface{}{
+			"current_page": page,
+			"total_pages":  (int(totalItems) + size - 1) / size,
+			"total_items":  totalItems,
+			"limit":        size,
+		},
+	})
+}
+
+// GetOrderByID godoc
+// @Summary Get an order
+// @Description Retrieve an order with its tickets; users can only see their own orders
+// @Tags Orders
+// @Produce json
+// @Param id path uint true "Order ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /orders/{id} [get]
+func (ctrl *OrderController) GetOrderByID(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid order ID", "data": nil})
+		return
+	}
+
+	order, err := ctrl.service.GetOrderForUser(uint(id), userID.(uint), role)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Order retrieved successfully", "data": order})
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"errors"
+	"eventix/payment"
+	"eventix/service"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentController struct {
+	service      service.PaymentService
+	fakeProvider *payment.FakeProvider
+}
+
+// NewPaymentController menerima fakeProvider hanya saat gateway lokal dipakai (boleh nil)
+func NewPaymentController(paymentService service.PaymentService, fakeProvider *payment.FakeProvider) *PaymentController {
+	return &PaymentController{
+		service:      paymentService,
+		fakeProvider: fakeProvider,
+	}
+}
+
+// HandleWebhook godoc
+// @Summary Payment provider webhook
+// @Description Receive a signed payment notification and move tickets to purchased or payment_failed
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string true "HMAC signature of the payload"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /payments/webhook [post]
+func (ctrl *PaymentController) HandleWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid webhook payload"})
+		return
+	}
+
+	if err := ctrl.service.HandleWebhook(payload, c.GetHeader("X-Payment-Signature")); err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Webhook processed successfully"})
+}
+
+// SimulateFakePayment godoc
+// @Summary Complete a payment on the local fake gateway
+// @Description Mark a fake payment intent as paid or failed and deliver the resulting webhook
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param intent_id path string true "Payment intent ID"
+// @Param request body map[string]bool true "{\"succeeded\": true}"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /payments/fake/{intent_id}/complete [post]
+func (ctrl *PaymentController) SimulateFakePayment(c *gin.Context) {
+	if ctrl.fakeProvider == nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": "Fake payment gateway is not enabled"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	// Hanya pemilik order yang boleh menyelesaikan intent-nya
+	if err := ctrl.service.AuthorizeIntent(c.Param("intent_id"), userID.(uint)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": "Payment intent not found"})
+		return
+	}
+
+	var reqBody struct {
+		Succeeded bool `json:"succeeded"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid data"})
+		return
+	}
+
+	payload, signature, err := ctrl.fakeProvider.Simulate(c.Param("intent_id"), reqBody.Succeeded)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	if err := ctrl.service.HandleWebhook(payload, signature); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Fake payment completed"})
+}
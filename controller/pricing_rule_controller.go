@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PricingRuleController struct {
+	service service.PricingRuleService
+}
+
+func NewPricingRuleController(pricingRuleService service.PricingRuleService) *PricingRuleController {
+	return &PricingRuleController{
+		service: pricingRuleService,
+	}
+}
+
+// GetEventPrice godoc
+// @Summary Preview the ticket price
+// @Description Calculate the current unit price and total for a quantity of tickets after dynamic pricing rules, without holding seats. Promo codes, service fees and taxes are not included
+// @Tags Pricing
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param quantity query int false "Number of seats (default 1)"
+// @Param ticket_type_id query uint false "Ticket type ID (required for events with ticket types)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /events/{id}/price [get]
+func (ctrl *PricingRuleController) GetEventPrice(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+	quantity, err := strconv.Atoi(c.DefaultQuery("quantity", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid quantity", "data": nil})
+		return
+	}
+
+	var ticketTypeID *uint
+	if value := c.Query("ticket_type_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket type ID", "data": nil})
+			return
+		}
+		typeID := uint(id)
+		ticketTypeID = &typeID
+	}
+
+	quote, err := ctrl.service.PreviewPrice(uint(eventID), ticketTypeID, quantity)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Price calculated successfully", "data": quote})
+}
+
+// GetPricingRules godoc
+// @Summary Get pricing rules of an event
+// @Description Retrieve the dynamic pricing rules of an event, highest priority first (Admin only)
+// @Tags Pricing
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/events/{id}/pricing-rules [get]
+func (ctrl *PricingRuleController) GetPricingRules(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	rules, err := ctrl.service.GetRulesByEventID(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Pricing rules retrieved successfully", "data": rules})
+}
+
+// CreatePricingRule godoc
+// @Summary Create a pricing rule
+// @Description Add a dynamic pricing rule. rule_type is early_bird (valid_until), sold_threshold (sold_threshold seats sold) or last_minute (hours_before_start); adjustment_type is percentage or amount (negative values are discounts) or price (new unit price). When several rules match, the highest priority wins, then the highest sold threshold (Admin only)
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param rule body entity.PricingRule true "Pricing rule details"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/events/{id}/pricing-rules [post]
+func (ctrl *PricingRuleController) CreatePricingRule(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	var rule entity.PricingRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid pricing rule data", "data": nil})
+		return
+	}
+	rule.EventID = uint(eventID)
+
+	createdRule, err := ctrl.service.CreateRule(rule)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Pricing rule created successfully", "data": createdRule})
+}
+
+// UpdatePricingRule godoc
+// @Summary Update a pricing rule
+// @Description Replace a pricing rule's settings, including is_active (Admin only)
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param rule_id path uint true "Pricing rule ID"
+// @Param rule body entity.PricingRule true "Pricing rule details"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/events/{id}/pricing-rules/{rule_id} [put]
+func (ctrl *PricingRuleController) UpdatePricingRule(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+	ruleID, err := strconv.ParseUint(c.Param("rule_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid pricing rule ID", "data": nil})
+		return
+	}
+
+	var rule entity.PricingRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid pricing rule data", "data": nil})
+		return
+	}
+	rule.ID = uint(ruleID)
+	rule.EventID = uint(eventID)
+
+	updatedRule, err := ctrl.service.UpdateRule(rule)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Pricing rule updated successfully", "data": updatedRule})
+}
+
+// DeletePricingRule godoc
+// @Summary Delete a pricing rule
+// @Description Delete a pricing rule that has not priced any ticket yet; used rules can only be deactivated (Admin only)
+// @Tags Pricing
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param rule_id path uint true "Pricing rule ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/events/{id}/pricing-rules/{rule_id} [delete]
+func (ctrl *PricingRuleController) DeletePricingRule(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+	ruleID, err := strconv.ParseUint(c.Param("rule_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid pricing rule ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.DeleteRule(uint(eventID), uint(ruleID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Pricing rule deleted successfully", "data": nil})
+}
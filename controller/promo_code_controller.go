@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromoCodeController struct {
+	service service.PromoCodeService
+}
+
+func NewPromoCodeController(promoCodeService service.PromoCodeService) *PromoCodeController {
+	return &PromoCodeController{
+		service: promoCodeService,
+	}
+}
+
+// GetAllPromoCodes godoc
+// @Summary Get all promo codes
+// @Description Retrieve every promo code (Admin only)
+// @Tags Promo Codes
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/promo-codes [get]
+func (ctrl *PromoCodeController) GetAllPromoCodes(c *gin.Context) {
+	promos, err := ctrl.service.GetAllPromoCodes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Promo codes retrieved successfully", "data": promos})
+}
+
+// GetPromoCodeByID godoc
+// @Summary Get a promo code
+// @Description Retrieve a promo code by ID (Admin only)
+// @Tags Promo Codes
+// @Produce json
+// @Param id path uint true "Promo code ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/promo-codes/{id} [get]
+func (ctrl *PromoCodeController) GetPromoCodeByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid promo code ID", "data": nil})
+		return
+	}
+
+	promo, err := ctrl.service.GetPromoCodeByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Promo code retrieved successfully", "data": promo})
+}
+
+// CreatePromoCode godoc
+// @Summary Create a promo code
+// @Description Create an active promo code. discount_type is percentage (value = percent off), fixed (value = amount off once per order) or buy_x_get_y (buy_quantity paid + get_quantity free per ticket line). Optional event_id/ticket_type_id scope, starts_at/ends_at window, max_uses and max_uses_per_user (0 = unlimited) (Admin only)
+// @Tags Promo Codes
+// @Accept json
+// @Produce json
+// @Param promo body entity.PromoCode true "Promo code details"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/promo-codes [post]
+func (ctrl *PromoCodeController) CreatePromoCode(c *gin.Context) {
+	var promo entity.PromoCode
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid promo code data", "data": nil})
+		return
+	}
+
+	createdPromo, err := ctrl.service.CreatePromoCode(promo)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Promo code created successfully", "data": createdPromo})
+}
+
+// UpdatePromoCode godoc
+// @Summary Update a promo code
+// @Description Replace a promo code's settings, including is_active; the code itself cannot be changed (Admin only)
+// @Tags Promo Codes
+// @Accept json
+// @Produce json
+// @Param id path uint true "Promo code ID"
+// @Param promo body entity.PromoCode true "Promo code details"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/promo-codes/{id} [put]
+func (ctrl *PromoCodeController) UpdatePromoCode(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid promo code ID", "data": nil})
+		return
+	}
+
+	var promo entity.PromoCode
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid promo code data", "data": nil})
+		return
+	}
+	promo.ID = uint(id)
+
+	updatedPromo, err := ctrl.service.UpdatePromoCode(promo)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrPromoCodeNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Promo code updated successfully", "data": updatedPromo})
+}
+
+// GetRedemptions godoc
+// @Summary Get promo code redemptions
+// @Description Retrieve the orders that used a promo code, newest first (Admin only)
+// @Tags Promo Codes
+// @Produce json
+// @Param id path uint true "Promo code ID"
+// @Param page query int false "Page number"
+// @Param size query int false "Page size"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/promo-codes/{id}/redemptions [get]
+func (ctrl *PromoCodeController) GetRedemptions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid promo code ID", "data": nil})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+
+	redemptions, totalItems, err := ctrl.service.GetRedemptions(uint(id), page, size)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Promo code redemptions retrieved successfully",
+		"data":    redemptions,
+		"meta": map[string]interface{}{
+			"current_page": page,
+			"total_pages":  (int(totalItems) + size - 1) / size,
+			"total_items":  totalItems,
+			"limit":        size,
+		},
+	})
+}
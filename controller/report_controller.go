@@ -69,3 +69,46 @@ func (ctrl *ReportController) GetSummaryReport(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Summary report retrieved successfully", "data": report})
 }
+
+// GetSeriesReport godoc
+// @Summary Get event series report
+// @Description Retrieve ticket sales and revenue aggregated across every occurrence of an event series, with a per-occurrence breakdown (Admin only)
+// @Tags Reports
+// @Produce json
+// @Param id path uint true "Series ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/reports/series/{id} [get]
+func (ctrl *ReportController) GetSeriesReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid series ID", "data": nil})
+		return
+	}
+
+	report, err := ctrl.service.GetSeriesReport(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Series report retrieved successfully", "data": report})
+}
+
+// GetPromoCodeReport godoc
+// @Summary Get promo code redemption report
+// @Description Retrieve redemptions, unique users, total discount and revenue per promo code; only paid orders count as redemptions (Admin only)
+// @Tags Reports
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/reports/promo-codes [get]
+func (ctrl *ReportController) GetPromoCodeReport(c *gin.Context) {
+	report, err := ctrl.service.GetPromoCodeReport()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Promo code report retrieved successfully", "data": report})
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReservationController struct {
+	service service.ReservationService
+}
+
+func NewReservationController(reservationService service.ReservationService) *ReservationController {
+	return &ReservationController{
+		service: reservationService,
+	}
+}
+
+// CreateReservation godoc
+// @Summary Hold seats
+// @Description Hold seats for an event (optionally of a ticket type) until the reservation expires
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param reservation body entity.Reservation true "Reservation details (event_id, ticket_type_id, quantity)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /reservations [post]
+func (ctrl *ReservationController) CreateReservation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	var reservation entity.Reservation
+	if err := c.ShouldBindJSON(&reservation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid reservation data", "data": nil})
+		return
+	}
+
+	// Assign user_id dari sesi login
+	reservation.UserID = userID.(uint)
+
+	createdReservation, err := ctrl.service.CreateReservation(reservation)
+	if err != nil {
+		status, code := purchaseErrorCode(err)
+		c.JSON(status, gin.H{"status": "error", "code": code, "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Seats held successfully", "data": createdReservation})
+}
+
+// GetReservation godoc
+// @Summary Get a reservation
+// @Description Retrieve a reservation owned by the logged-in user
+// @Tags Reservations
+// @Produce json
+// @Param id path uint true "Reservation ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /reservations/{id} [get]
+func (ctrl *ReservationController) GetReservation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid reservation ID", "data": nil})
+		return
+	}
+
+	reservation, err := ctrl.service.GetReservationByID(uint(id), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Reservation retrieved successfully", "data": reservation})
+}
+
+// ConfirmReservation godoc
+// @Summary Confirm a reservation
+// @Description Convert an active hold into purchased tickets, optionally applying a promo code
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param id path uint true "Reservation ID"
+// @Param body body object false "Optional promo code, e.g. {\"promo_code\": \"EARLY10\"}"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /reservations/{id}/confirm [post]
+func (ctrl *ReservationController) ConfirmReservation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid reservation ID", "data": nil})
+		return
+	}
+
+	// Kode promo bersifat opsional
+	var reqBody struct {
+		PromoCode string `json:"promo_code"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid data", "data": nil})
+			return
+		}
+	}
+
+	ticket, err := ctrl.service.ConfirmReservation(uint(id), userID.(uint), reqBody.PromoCode)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Reservation confirmed successfully", "data": ticket})
+}
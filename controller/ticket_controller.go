@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"eventix/entity"
 	"eventix/service"
 	"net/http"
@@ -66,13 +67,14 @@ func (ctrl *TicketController) GetTickets(c *gin.Context) {
 
 // CreateTicket godoc
 // @Summary Purchase a ticket
-// @Description User can purchase tickets for an event
+// @Description User can purchase tickets for an event; an optional promo_code is applied to the ticket price
 // @Tags Tickets
 // @Accept json
 // @Produce json
 // @Param ticket body entity.Ticket true "Ticket details"
 // @Success 201 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /tickets [post]
 func (ctrl *TicketController) CreateTicket(c *gin.Context) {
@@ -93,7 +95,8 @@ func (ctrl *TicketController) CreateTicket(c *gin.Context) {
 
 	createdTicket, err := ctrl.service.CreateTicket(ticket)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		status, code := purchaseErrorCode(err)
+		c.JSON(status, gin.H{"status": "error", "code": code, "message": err.Error(), "data": nil})
 		return
 	}
 
@@ -101,35 +104,267 @@ func (ctrl *TicketController) CreateTicket(c *gin.Context) {
 }
 
 
+// CancelTicket godoc
+// @Summary Cancel a ticket
+// @Description Cancel a purchased ticket owned by the logged-in user (Admins may cancel any ticket); the refund amount follows the event refund policy
+// @Tags Tickets
+// @Accept json
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Param request body map[string]string false "Cancellation reason"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id} [patch]
 func (ctrl *TicketController) CancelTicket(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
 		return
 	}
 
-	if err := ctrl.service.CancelTicket(uint(id)); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+	// Alasan pembatalan bersifat opsional
+	var reqBody struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid data", "data": nil})
+			return
+		}
+	}
+
+	refund, err := ctrl.service.CancelTicket(uint(id), userID.(uint), role, reqBody.Reason)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket cancelled successfully", "data": nil})
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket cancelled successfully", "data": gin.H{
+		"refund_amount":  refund.Amount,
+		"refund_percent": refund.Percent,
+		"refund":         refund,
+	}})
 }
 
+// GetTicketByID godoc
+// @Summary Get a ticket
+// @Description Retrieve a ticket owned by the logged-in user (Admins may read any ticket)
+// @Tags Tickets
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id} [get]
 func (ctrl *TicketController) GetTicketByID(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
 		return
 	}
-	ticket, err := ctrl.service.GetTicketByID(uint(id))
+	ticket, err := ctrl.service.GetTicketForUser(uint(id), userID.(uint), role)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to retrieve ticket", "data": nil})
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket retrieved successfully", "data": ticket})
 }
 
+// GetTicketQR godoc
+// @Summary Get ticket QR code
+// @Description Render the signed ticket payload as a PNG QR code (purchased tickets only)
+// @Tags Tickets
+// @Produce png
+// @Param id path uint true "Ticket ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id}/qr [get]
+func (ctrl *TicketController) GetTicketQR(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
+		return
+	}
+
+	image, err := ctrl.service.GetTicketQR(uint(id), userID.(uint), role)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// CancelTicketItem godoc
+// @Summary Cancel one seat of a ticket
+// @Description Cancel a single seat; the ticket is cancelled once no active seat remains
+// @Tags Tickets
+// @Accept json
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Param item_id path uint true "Ticket item ID"
+// @Param request body map[string]string false "Cancellation reason"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id}/items/{item_id} [patch]
+func (ctrl *TicketController) CancelTicketItem(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
+	id, itemID, ok := parseTicketItemParams(c)
+	if !ok {
+		return
+	}
+
+	// Alasan pembatalan bersifat opsional
+	var reqBody struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid data", "data": nil})
+			return
+		}
+	}
+
+	refund, err := ctrl.service.CancelTicketItem(id, itemID, userID.(uint), role, reqBody.Reason)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket item cancelled successfully", "data": gin.H{
+		"refund_amount":  refund.Amount,
+		"refund_percent": refund.Percent,
+		"refund":         refund,
+	}})
+}
+
+// UpdateTicketItemHolder godoc
+// @Summary Update seat holder
+// @Description Set the attendee name and email of a single seat
+// @Tags Tickets
+// @Accept json
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Param item_id path uint true "Ticket item ID"
+// @Param request body map[string]string true "holder_name and holder_email"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id}/items/{item_id} [put]
+func (ctrl *TicketController) UpdateTicketItemHolder(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
+	id, itemID, ok := parseTicketItemParams(c)
+	if !ok {
+		return
+	}
+
+	var reqBody struct {
+		HolderName  string `json:"holder_name" binding:"required"`
+		HolderEmail string `json:"holder_email"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid data", "data": nil})
+		return
+	}
+
+	item, err := ctrl.service.UpdateTicketItemHolder(id, itemID, userID.(uint), role, reqBody.HolderName, reqBody.HolderEmail)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket item updated successfully", "data": item})
+}
+
+// GetTicketItemQR godoc
+// @Summary Get seat QR code
+// @Description Render the signed payload of a single seat as a PNG QR code
+// @Tags Tickets
+// @Produce png
+// @Param id path uint true "Ticket ID"
+// @Param item_id path uint true "Ticket item ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id}/items/{item_id}/qr [get]
+func (ctrl *TicketController) GetTicketItemQR(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
+	id, itemID, ok := parseTicketItemParams(c)
+	if !ok {
+		return
+	}
+
+	image, err := ctrl.service.GetTicketItemQR(id, itemID, userID.(uint), role)
+	if err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// parseTicketItemParams membaca :id dan :item_id; mengirim respons 400 jika tidak valid
+func parseTicketItemParams(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
+		return 0, 0, false
+	}
+	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket item ID", "data": nil})
+		return 0, 0, false
+	}
+	return uint(id), uint(itemID), true
+}
+
 func (ctrl *TicketController) UpdateTicket(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -173,3 +408,53 @@ func (ctrl *TicketController) SearchAndFilterTickets(c *gin.Context) {
 
     c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Tickets retrieved successfully", "data": result})
 }
+
+// ticketErrorStatus memetakan error layanan tiket ke HTTP status
+func ticketErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTicketNotFound), errors.Is(err, service.ErrTicketItemNotFound), errors.Is(err, service.ErrInvoiceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTicketForbidden):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// purchaseErrorCode memetakan error batas pembelian ke HTTP status dan kode error yang bisa dibaca klien
+func purchaseErrorCode(err error) (int, string) {
+	switch {
+	case errors.Is(err, service.ErrEventNotAvailable):
+		return http.StatusUnprocessableEntity, "EVENT_NOT_AVAILABLE"
+	case errors.Is(err, service.ErrSalesNotStarted):
+		return http.StatusUnprocessableEntity, "SALES_NOT_STARTED"
+	case errors.Is(err, service.ErrSalesEnded):
+		return http.StatusUnprocessableEntity, "SALES_ENDED"
+	case errors.Is(err, service.ErrSeatUnavailable):
+		return http.StatusConflict, "SEAT_UNAVAILABLE"
+	case errors.Is(err, service.ErrInvalidQuantity):
+		return http.StatusBadRequest, "INVALID_QUANTITY"
+	case errors.Is(err, service.ErrBelowMinPerOrder):
+		return http.StatusUnprocessableEntity, "BELOW_MIN_PER_ORDER"
+	case errors.Is(err, service.ErrAboveMaxPerOrder):
+		return http.StatusUnprocessableEntity, "ABOVE_MAX_PER_ORDER"
+	case errors.Is(err, service.ErrAboveMaxPerUser):
+		return http.StatusUnprocessableEntity, "ABOVE_MAX_PER_USER"
+	case errors.Is(err, service.ErrCartEmpty):
+		return http.StatusBadRequest, "CART_EMPTY"
+	case errors.Is(err, service.ErrCurrencyMismatch):
+		return http.StatusUnprocessableEntity, "CURRENCY_MISMATCH"
+	case errors.Is(err, service.ErrPromoCodeNotFound):
+		return http.StatusUnprocessableEntity, "PROMO_CODE_NOT_FOUND"
+	case errors.Is(err, service.ErrPromoCodeNotActive):
+		return http.StatusUnprocessableEntity, "PROMO_CODE_NOT_ACTIVE"
+	case errors.Is(err, service.ErrPromoCodeUsedUp):
+		return http.StatusUnprocessableEntity, "PROMO_CODE_USED_UP"
+	case errors.Is(err, service.ErrPromoCodeUserLimit):
+		return http.StatusUnprocessableEntity, "PROMO_CODE_USER_LIMIT"
+	case errors.Is(err, service.ErrPromoCodeNotApplicable):
+		return http.StatusUnprocessableEntity, "PROMO_CODE_NOT_APPLICABLE"
+	default:
+		return http.StatusBadRequest, "PURCHASE_FAILED"
+	}
+}
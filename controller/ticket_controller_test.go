@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubTicketService mengembalikan error yang sama untuk setiap akses tiket
+type stubTicketService struct {
+	service.TicketService
+	err error
+}
+
+func (s stubTicketService) GetTicketForUser(id uint, actorID uint, actorRole string) (entity.Ticket, error) {
+	return entity.Ticket{}, s.err
+}
+
+func (s stubTicketService) GetTicketQR(id uint, actorID uint, actorRole string) ([]byte, error) {
+	return nil, s.err
+}
+
+func (s stubTicketService) CancelTicket(ticketID uint, actorID uint, actorRole string, reason string) (entity.Refund, error) {
+	return entity.Refund{}, s.err
+}
+
+func TestTicketAccessErrorStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/tickets/1"},
+		{http.MethodGet, "/tickets/1/qr"},
+		{http.MethodPatch, "/tickets/1"},
+	}
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrTicketForbidden, http.StatusForbidden},
+		{service.ErrTicketNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		ctrl := NewTicketController(stubTicketService{err: tt.err})
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set("user_id", uint(20))
+			c.Set("role", "User")
+		})
+		r.GET("/tickets/:id", ctrl.GetTicketByID)
+		r.GET("/tickets/:id/qr", ctrl.GetTicketQR)
+		r.PATCH("/tickets/:id", ctrl.CancelTicket)
+
+		for _, route := range routes {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(route.method, route.path, nil))
+			if w.Code != tt.want {
+				t.Errorf("%s %s with %v: status %d, want %d", route.method, route.path, tt.err, w.Code, tt.want)
+			}
+		}
+	}
+}
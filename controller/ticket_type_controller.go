@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketTypeController struct {
+	service service.TicketTypeService
+}
+
+func NewTicketTypeController(ticketTypeService service.TicketTypeService) *TicketTypeController {
+	return &TicketTypeController{
+		service: ticketTypeService,
+	}
+}
+
+// GetTicketTypes godoc
+// @Summary Get ticket types of an event
+// @Description Retrieve the price categories (VIP, Regular, Early Bird, ...) of an event
+// @Tags Ticket Types
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/ticket-types [get]
+func (ctrl *TicketTypeController) GetTicketTypes(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	ticketTypes, err := ctrl.service.GetTicketTypesByEventID(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket types retrieved successfully", "data": ticketTypes})
+}
+
+// CreateTicketType godoc
+// @Summary Create a ticket type
+// @Description Admin can add a price category with its own quota, sale window and per-order limit
+// @Tags Ticket Types
+// @Accept json
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param ticket_type body entity.TicketType true "Ticket type details"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/events/{id}/ticket-types [post]
+func (ctrl *TicketTypeController) CreateTicketType(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	var ticketType entity.TicketType
+	if err := c.ShouldBindJSON(&ticketType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket type data", "data": nil})
+		return
+	}
+	ticketType.ID = 0
+	ticketType.EventID = uint(eventID)
+
+	createdTicketType, err := ctrl.service.CreateTicketType(ticketType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Ticket type created successfully", "data": createdTicketType})
+}
+
+// UpdateTicketType godoc
+// @Summary Update a ticket type
+// @Description Admin can change the name, price, quota, sale window and per-order limit of a ticket type
+// @Tags Ticket Types
+// @Accept json
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param type_id path uint true "Ticket type ID"
+// @Param ticket_type body entity.TicketType true "Ticket type details"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/events/{id}/ticket-types/{type_id} [put]
+func (ctrl *TicketTypeController) UpdateTicketType(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+	typeID, err := strconv.ParseUint(c.Param("type_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket type ID", "data": nil})
+		return
+	}
+
+	var ticketType entity.TicketType
+	if err := c.ShouldBindJSON(&ticketType); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket type data", "data": nil})
+		return
+	}
+	ticketType.ID = uint(typeID)
+	ticketType.EventID = uint(eventID)
+
+	updatedTicketType, err := ctrl.service.UpdateTicketType(ticketType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket type updated successfully", "data": updatedTicketType})
+}
+
+// DeleteTicketType godoc
+// @Summary Delete a ticket type
+// @Description Admin can delete a ticket type that has no sales yet
+// @Tags Ticket Types
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param type_id path uint true "Ticket type ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/events/{id}/ticket-types/{type_id} [delete]
+func (ctrl *TicketTypeController) DeleteTicketType(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+	typeID, err := strconv.ParseUint(c.Param("type_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket type ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.DeleteTicketType(uint(eventID), uint(typeID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket type deleted successfully", "data": nil})
+}
@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferController struct {
+	service service.TransferService
+}
+
+func NewTransferController(transferService service.TransferService) *TransferController {
+	return &TransferController{
+		service: transferService,
+	}
+}
+
+// RequestTransfer godoc
+// @Summary Transfer a ticket
+// @Description Offer a purchased ticket to another user; ownership moves once the recipient accepts
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Param transfer body object true "Recipient (username)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /tickets/{id}/transfer [post]
+func (ctrl *TransferController) RequestTransfer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
+		return
+	}
+
+	var reqBody struct {
+		Username string `json:"username" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Recipient username is required", "data": nil})
+		return
+	}
+
+	transfer, err := ctrl.service.RequestTransfer(uint(id), userID.(uint), reqBody.Username)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Transfer requested successfully", "data": transfer})
+}
+
+// RequestItemTransfer godoc
+// @Summary Transfer a single seat
+// @Description Offer one seat of a purchased ticket to another user; once accepted the seat moves to a new ticket owned by the recipient with a new code
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Param item_id path uint true "Ticket item ID"
+// @Param transfer body object true "Recipient (username)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /tickets/{id}/items/{item_id}/transfer [post]
+func (ctrl *TransferController) RequestItemTransfer(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, itemID, ok := parseTicketItemParams(c)
+	if !ok {
+		return
+	}
+
+	var reqBody struct {
+		Username string `json:"username" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Recipient username is required", "data": nil})
+		return
+	}
+
+	transfer, err := ctrl.service.RequestItemTransfer(id, itemID, userID.(uint), reqBody.Username)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Transfer requested successfully", "data": transfer})
+}
+
+// GetTicketTransfers godoc
+// @Summary Get ticket transfer history
+// @Description Audit trail of every transfer of a ticket, including previous owners
+// @Tags Transfers
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /tickets/{id}/transfers [get]
+func (ctrl *TransferController) GetTicketTransfers(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+	role := c.GetString("role")
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
+		return
+	}
+
+	transfers, err := ctrl.service.GetTicketTransfers(uint(id), userID.(uint), role)
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Transfers retrieved successfully", "data": transfers})
+}
+
+// GetPendingTransfers godoc
+// @Summary List pending transfers
+// @Description List incoming and outgoing transfers of the logged-in user that are still awaiting a response
+// @Tags Transfers
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /transfers [get]
+func (ctrl *TransferController) GetPendingTransfers(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	transfers, err := ctrl.service.GetPendingTransfers(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Transfers retrieved successfully", "data": transfers})
+}
+
+// AcceptTransfer godoc
+// @Summary Accept a transfer
+// @Description Take ownership of a transferred ticket; ticket and seat codes are regenerated
+// @Tags Transfers
+// @Produce json
+// @Param id path uint true "Transfer ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /transfers/{id}/accept [post]
+func (ctrl *TransferController) AcceptTransfer(c *gin.Context) {
+	ctrl.respond(c, ctrl.service.AcceptTransfer, "Transfer accepted successfully")
+}
+
+// DeclineTransfer godoc
+// @Summary Decline a transfer
+// @Description Decline an incoming transfer; the ticket stays with the sender
+// @Tags Transfers
+// @Produce json
+// @Param id path uint true "Transfer ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /transfers/{id}/decline [post]
+func (ctrl *TransferController) DeclineTransfer(c *gin.Context) {
+	ctrl.respond(c, ctrl.service.DeclineTransfer, "Transfer declined successfully")
+}
+
+// CancelTransfer godoc
+// @Summary Cancel a transfer
+// @Description Withdraw an outgoing transfer that has not been answered yet
+// @Tags Transfers
+// @Produce json
+// @Param id path uint true "Transfer ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /transfers/{id}/cancel [post]
+func (ctrl *TransferController) CancelTransfer(c *gin.Context) {
+	ctrl.respond(c, ctrl.service.CancelTransfer, "Transfer cancelled successfully")
+}
+
+// respond menjalankan aksi jawaban transfer untuk user yang sedang login
+func (ctrl *TransferController) respond(c *gin.Context, action func(transferID uint, userID uint) (entity.TicketTransfer, error), message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid transfer ID", "data": nil})
+		return
+	}
+
+	transfer, err := action(uint(id), userID.(uint))
+	if err != nil {
+		c.JSON(transferErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": message, "data": transfer})
+}
+
+// transferErrorStatus memetakan error layanan transfer ke HTTP status
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTransferNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTransferForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrTransferPending):
+		return http.StatusConflict
+	default:
+		return ticketErrorStatus(err)
+	}
+}
@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashController struct {
+	service service.TrashService
+}
+
+func NewTrashController(trashService service.TrashService) *TrashController {
+	return &TrashController{
+		service: trashService,
+	}
+}
+
+// GetDeletedEvents godoc
+// @Summary List deleted events
+// @Description Retrieve soft-deleted events that can still be restored (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param page query int false "Page number"
+// @Param size query int false "Page size"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/trash/events [get]
+func (ctrl *TrashController) GetDeletedEvents(c *gin.Context) {
+	page, size := trashPagination(c)
+
+	events, totalItems, err := ctrl.service.GetDeletedEvents(page, size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to retrieve deleted events", "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Deleted events retrieved successfully", "data": events, "meta": trashMeta(page, size, totalItems)})
+}
+
+// RestoreEvent godoc
+// @Summary Restore a deleted event
+// @Description Restore a soft-deleted event (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/trash/events/{id}/restore [post]
+func (ctrl *TrashController) RestoreEvent(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.RestoreEvent(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Event restored successfully", "data": nil})
+}
+
+// DeleteTicket godoc
+// @Summary Delete a ticket
+// @Description Soft-delete a cancelled or failed ticket (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/tickets/{id} [delete]
+func (ctrl *TrashController) DeleteTicket(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.DeleteTicket(uint(id)); err != nil {
+		c.JSON(ticketErrorStatus(err), gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket deleted successfully", "data": nil})
+}
+
+// GetDeletedTickets godoc
+// @Summary List deleted tickets
+// @Description Retrieve soft-deleted tickets that can still be restored (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param page query int false "Page number"
+// @Param size query int false "Page size"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/trash/tickets [get]
+func (ctrl *TrashController) GetDeletedTickets(c *gin.Context) {
+	page, size := trashPagination(c)
+
+	tickets, totalItems, err := ctrl.service.GetDeletedTickets(page, size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to retrieve deleted tickets", "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Deleted tickets retrieved successfully", "data": tickets, "meta": trashMeta(page, size, totalItems)})
+}
+
+// RestoreTicket godoc
+// @Summary Restore a deleted ticket
+// @Description Restore a soft-deleted ticket (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param id path uint true "Ticket ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/trash/tickets/{id}/restore [post]
+func (ctrl *TrashController) RestoreTicket(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid ticket ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.RestoreTicket(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Ticket restored successfully", "data": nil})
+}
+
+// DeleteUser godoc
+// @Summary Delete a user
+// @Description Soft-delete a user account; the user can no longer log in (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param id path uint true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/users/{id} [delete]
+func (ctrl *TrashController) DeleteUser(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid user ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.DeleteUser(uint(id), adminID.(uint)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "User deleted successfully", "data": nil})
+}
+
+// GetDeletedUsers godoc
+// @Summary List deleted users
+// @Description Retrieve soft-deleted users that can still be restored (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param page query int false "Page number"
+// @Param size query int false "Page size"
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /admin/trash/users [get]
+func (ctrl *TrashController) GetDeletedUsers(c *gin.Context) {
+	page, size := trashPagination(c)
+
+	users, totalItems, err := ctrl.service.GetDeletedUsers(page, size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": "Failed to retrieve deleted users", "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Deleted users retrieved successfully", "data": users, "meta": trashMeta(page, size, totalItems)})
+}
+
+// RestoreUser godoc
+// @Summary Restore a deleted user
+// @Description Restore a soft-deleted user account (Admin only)
+// @Tags Trash
+// @Produce json
+// @Param id path uint true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/trash/users/{id}/restore [post]
+func (ctrl *TrashController) RestoreUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid user ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.RestoreUser(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "User restored successfully", "data": nil})
+}
+
+func trashPagination(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = 10
+	}
+	return page, size
+}
+
+func trashMeta(page int, size int, totalItems int64) map[string]interface{} {
+	return map[string]interface{}{
+		"current_page": page,
+		"total_pages":  (int(totalItems) + size - 1) / size,
+		"total_items":  totalItems,
+		"limit":        size,
+	}
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueController struct {
+	service service.VenueService
+}
+
+func NewVenueController(venueService service.VenueService) *VenueController {
+	return &VenueController{
+		service: venueService,
+	}
+}
+
+// GetAllVenues godoc
+// @Summary Get all venues
+// @Description Retrieve every venue without its seat map (Admin only)
+// @Tags Venues
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /admin/venues [get]
+func (ctrl *VenueController) GetAllVenues(c *gin.Context) {
+	venues, err := ctrl.service.GetAllVenues()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Venues retrieved successfully", "data": venues})
+}
+
+// GetVenueByID godoc
+// @Summary Get a venue with its seat map
+// @Description Retrieve a venue with its sections, rows and seats (Admin only)
+// @Tags Venues
+// @Produce json
+// @Param id path uint true "Venue ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /admin/venues/{id} [get]
+func (ctrl *VenueController) GetVenueByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid venue ID", "data": nil})
+		return
+	}
+
+	venue, err := ctrl.service.GetVenueSeatMap(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Venue retrieved successfully", "data": venue})
+}
+
+// CreateVenue godoc
+// @Summary Create a venue
+// @Description Create a venue; its seat map is imported separately (Admin only)
+// @Tags Venues
+// @Accept json
+// @Produce json
+// @Param venue body entity.Venue true "Venue details (name, address, city)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/venues [post]
+func (ctrl *VenueController) CreateVenue(c *gin.Context) {
+	var venue entity.Venue
+	if err := c.ShouldBindJSON(&venue); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid venue data", "data": nil})
+		return
+	}
+
+	createdVenue, err := ctrl.service.CreateVenue(venue)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Venue created successfully", "data": createdVenue})
+}
+
+// ImportSeatMap godoc
+// @Summary Import a venue seat map
+// @Description Replace a venue's seat map from JSON ({"sections":[{"name","rows":[{"label","seats":[...]}]}]}) or CSV (section,row,seat). Rejected once any seat has been sold (Admin only)
+// @Tags Venues
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param id path uint true "Venue ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /admin/venues/{id}/seat-map [post]
+func (ctrl *VenueController) ImportSeatMap(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid venue ID", "data": nil})
+		return
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid seat map data", "data": nil})
+		return
+	}
+
+	// Format ditentukan dari Content-Type; selain CSV dianggap JSON
+	format := "json"
+	if strings.Contains(c.ContentType(), "csv") {
+		format = "csv"
+	}
+
+	venue, err := ctrl.service.ImportSeatMap(uint(id), format, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Seat map imported successfully", "data": venue})
+}
+
+// GetEventSeats godoc
+// @Summary Get the seat map of an event
+// @Description Retrieve the event venue's seat map with each seat marked available or taken
+// @Tags Venues
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/seats [get]
+func (ctrl *VenueController) GetEventSeats(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	venue, err := ctrl.service.GetEventSeats(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Seat map retrieved successfully", "data": venue})
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"eventix/entity"
+	"eventix/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistController struct {
+	service service.WaitlistService
+}
+
+func NewWaitlistController(waitlistService service.WaitlistService) *WaitlistController {
+	return &WaitlistController{
+		service: waitlistService,
+	}
+}
+
+// JoinWaitlist godoc
+// @Summary Join an event waitlist
+// @Description Queue for a sold-out event; when seats are released the next users in line receive a time-limited reservation
+// @Tags Waitlist
+// @Accept json
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Param entry body entity.WaitlistEntry true "Waitlist details (quantity, ticket_type_id)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /events/{id}/waitlist [post]
+func (ctrl *WaitlistController) JoinWaitlist(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	var entry entity.WaitlistEntry
+	if err := c.ShouldBindJSON(&entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid waitlist data", "data": nil})
+		return
+	}
+
+	// Event dan user diambil dari URL dan sesi login
+	entry.EventID = uint(eventID)
+	entry.UserID = userID.(uint)
+
+	createdEntry, err := ctrl.service.JoinWaitlist(entry)
+	if err != nil {
+		status, code := purchaseErrorCode(err)
+		c.JSON(status, gin.H{"status": "error", "code": code, "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "success", "message": "Joined waitlist successfully", "data": createdEntry})
+}
+
+// GetWaitlistEntry godoc
+// @Summary Get waitlist position
+// @Description Retrieve the logged-in user's waitlist entry and position for an event
+// @Tags Waitlist
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/waitlist [get]
+func (ctrl *WaitlistController) GetWaitlistEntry(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	entry, err := ctrl.service.GetWaitlistEntry(uint(eventID), userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Waitlist entry retrieved successfully", "data": entry})
+}
+
+// LeaveWaitlist godoc
+// @Summary Leave an event waitlist
+// @Description Remove the logged-in user from the waitlist; an outstanding offer is released to the next user
+// @Tags Waitlist
+// @Produce json
+// @Param id path uint true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /events/{id}/waitlist [delete]
+func (ctrl *WaitlistController) LeaveWaitlist(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"status": "error", "message": "Unauthorized"})
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "Invalid event ID", "data": nil})
+		return
+	}
+
+	if err := ctrl.service.LeaveWaitlist(uint(eventID), userID.(uint)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"status": "error", "message": err.Error(), "data": nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success", "message": "Left waitlist successfully", "data": nil})
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"eventix/money"
+	"time"
+)
+
+// CartItem adalah satu baris keranjang user; harga dihitung ulang saat keranjang dibaca dan saat checkout
+type CartItem struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	UserID       uint        `gorm:"index;not null" json:"user_id"`
+	EventID      uint        `gorm:"not null" json:"event_id"`
+	TicketTypeID *uint       `json:"ticket_type_id"`
+	Quantity     int         `json:"quantity"`
+	EventName    string      `gorm:"-" json:"event_name,omitempty"`
+	UnitPrice    money.Money `gorm:"-" json:"unit_price"`
+	Subtotal     money.Money `gorm:"-" json:"subtotal"`
+	Available    bool        `gorm:"-" json:"available"` // Baris masih bisa dibeli dengan jumlah tersebut
+	CreatedAt    time.Time   `gorm:"<-:create" json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// Cart adalah isi keranjang beserta perkiraan total pesanan
+type Cart struct {
+	Items      []CartItem  `json:"items"`
+	Quantity   int         `json:"quantity"`
+	Subtotal   money.Money `json:"subtotal"`
+	ServiceFee money.Money `json:"service_fee"`
+	Tax        money.Money `json:"tax"`
+	Total      money.Money `json:"total"`
+}
@@ -1,17 +1,85 @@
 package entity
 
-import "time"
+import (
+	"eventix/money"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Event struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Name        string    `gorm:"type:varchar(255);index" json:"name"`
-	Description string    `json:"description"`
-	StartDate   time.Time `json:"start_date"`
-	EndDate     time.Time `json:"end_date"`
-	Capacity    int       `json:"capacity"`
-	Price       float64   `json:"price"` // Harga tiket untuk event
-	Status      string    `gorm:"type:varchar(50);default:'active'" json:"status"` // Status event: active, ongoing, completed
-	ImageURL    string    `gorm:"type:varchar(255)" json:"image_url"` // URL gambar untuk event
-	CreatedAt   time.Time `gorm:"<-:create" json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                   uint           `gorm:"primaryKey" json:"id"`
+	Name                 string         `gorm:"type:varchar(255);index" json:"name"`
+	Description          string         `json:"description"`
+	StartDate            time.Time      `json:"start_date"`
+	EndDate              time.Time      `json:"end_date"`
+	Capacity             int            `json:"capacity"` // Kapasitas awal event, tidak berubah saat tiket terjual
+	SoldCount            int            `gorm:"default:0" json:"sold_count"`
+	ReservedCount        int            `gorm:"default:0" json:"reserved_count"`
+	Price                money.Money    `gorm:"embedded;embeddedPrefix:price_" json:"price"`               // Harga tiket untuk event; mata uangnya menjadi mata uang event
+	Status               string         `gorm:"type:varchar(50);default:'active'" json:"status"`           // Status event: draft, active, ongoing, completed, cancelled
+	VenueID              *uint          `gorm:"index" json:"venue_id"`                                     // Lokasi event; kosong berarti belum ditentukan
+	SeriesID             *uint          `gorm:"uniqueIndex:idx_series_occurrence" json:"series_id"`        // Seri asal jika event ini kemunculan event berulang
+	OccurrenceStart      *time.Time     `gorm:"uniqueIndex:idx_series_occurrence" json:"occurrence_start"` // Waktu mulai kemunculan menurut aturan seri, tetap walau jadwalnya diubah
+	SeriesOverride       bool           `gorm:"default:false" json:"series_override"`                      // Kemunculan diubah sendiri sehingga tidak lagi mengikuti perubahan template seri
+	ImageURL             string         `gorm:"type:varchar(255)" json:"image_url"`                        // URL gambar untuk event
+	RefundFullDaysBefore int            `gorm:"default:0" json:"refund_full_days_before"`                  // Refund penuh jika dibatalkan paling lambat X hari sebelum event
+	RefundPartialPercent int            `gorm:"default:0" json:"refund_partial_percent"`                   // Persentase refund setelah batas refund penuh
+	SalesStartAt         *time.Time     `json:"sales_start_at"`                                            // Penjualan dibuka; kosong berarti langsung dibuka
+	SalesEndAt           *time.Time     `json:"sales_end_at"`                                              // Penjualan ditutup; kosong berarti saat event dimulai
+	SalesState           string         `gorm:"-" json:"sales_state"`                                      // Status penjualan saat dibaca: upcoming, on_sale, ended
+	MinPerOrder          int            `gorm:"default:0" json:"min_per_order"`                            // Minimal kursi per pesanan, 0 berarti 1
+	MaxPerOrder          int            `gorm:"default:0" json:"max_per_order"`                            // Maksimal kursi per pesanan, 0 berarti tanpa batas
+	MaxPerUser           int            `gorm:"default:0" json:"max_per_user"`                             // Maksimal kursi per user untuk seluruh pesanan, 0 berarti tanpa batas
+	TransferPolicy       string         `gorm:"type:varchar(20);default:'allowed'" json:"transfer_policy"` // Kebijakan transfer tiket: allowed, disallowed
+	TransferCutoffHours  int            `gorm:"default:0" json:"transfer_cutoff_hours"`                    // Transfer ditutup X jam sebelum event dimulai
+	CreatedAt            time.Time      `gorm:"<-:create" json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Soft delete; baris terhapus tidak ikut query biasa
+}
+
+// AvailableCapacity menghitung sisa kursi yang masih bisa dijual
+func (e Event) AvailableCapacity() int {
+	return e.Capacity - e.SoldCount - e.ReservedCount
+}
+
+// SalesClosesAt mengembalikan batas akhir penjualan; default-nya saat event dimulai
+func (e Event) SalesClosesAt() time.Time {
+	if e.SalesEndAt != nil {
+		return *e.SalesEndAt
+	}
+	return e.StartDate
+}
+
+// SalesStateAt menentukan status penjualan event pada waktu now: upcoming, on_sale, atau ended
+func (e Event) SalesStateAt(now time.Time) string {
+	if e.SalesStartAt != nil && now.Before(*e.SalesStartAt) {
+		return "upcoming"
+	}
+	if !now.Before(e.SalesClosesAt()) {
+		return "ended"
+	}
+	return "on_sale"
+}
+
+// RefundPercent menghitung persentase refund untuk pembatalan pada waktu now
+// berdasarkan kebijakan refund event: penuh, sebagian, atau tidak ada setelah event dimulai.
+// Event yang dibatalkan penyelenggara selalu direfund penuh.
+func (e Event) RefundPercent(now time.Time) int {
+	if e.Status == "cancelled" {
+		return 100
+	}
+	if !now.Before(e.StartDate) {
+		return 0
+	}
+	fullRefundDeadline := e.StartDate.AddDate(0, 0, -e.RefundFullDaysBefore)
+	if !now.After(fullRefundDeadline) {
+		return 100
+	}
+	return e.RefundPartialPercent
+}
+
+// TransferDeadline mengembalikan batas akhir transfer tiket sesuai kebijakan event
+func (e Event) TransferDeadline() time.Time {
+	return e.StartDate.Add(-time.Duration(e.TransferCutoffHours) * time.Hour)
 }
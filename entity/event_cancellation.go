@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"eventix/money"
+	"time"
+)
+
+// EventCancellation mencatat progres pembatalan massal tiket ketika event dibatalkan admin
+type EventCancellation struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	EventID          uint        `gorm:"index;not null" json:"event_id"`
+	RequestedBy      uint        `json:"requested_by"`
+	Reason           string      `gorm:"type:varchar(255)" json:"reason"`
+	Status           string      `gorm:"type:varchar(20);index" json:"status"` // Status: running, completed_with_errors, completed
+	TotalTickets     int64       `json:"total_tickets"`
+	ProcessedTickets int64       `gorm:"default:0" json:"processed_tickets"`
+	FailedTickets    int64       `gorm:"default:0" json:"failed_tickets"`
+	RefundedAmount   money.Money `gorm:"embedded;embeddedPrefix:refunded_total_" json:"refunded_amount"`
+	LastTicketID     uint        `gorm:"default:0" json:"last_ticket_id"` // Kursor batch; tiket dengan ID lebih kecil sudah diproses
+	LastError        string      `gorm:"type:varchar(255)" json:"last_error"`
+	CompletedAt      *time.Time  `json:"completed_at"`
+	CreatedAt        time.Time   `gorm:"<-:create" json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// EventSeat menandai kursi venue yang sudah diambil untuk satu event.
+// Unique index (event_id, seat_id) menjamin satu kursi tidak bisa dijual dua kali.
+type EventSeat struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	EventID      uint      `gorm:"uniqueIndex:idx_event_seat;not null" json:"event_id"`
+	SeatID       uint      `gorm:"uniqueIndex:idx_event_seat;not null" json:"seat_id"`
+	TicketID     uint      `gorm:"index;not null" json:"ticket_id"`
+	TicketItemID uint      `gorm:"index;not null" json:"ticket_item_id"`
+	CreatedAt    time.Time `gorm:"<-:create" json:"created_at"`
+}
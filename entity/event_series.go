@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"eventix/money"
+	"time"
+)
+
+// EventSeries adalah template event berulang; setiap kemunculan dibuat sebagai Event biasa
+// sesuai aturan pengulangan (RRULE) sampai batas horizon materialisasi
+type EventSeries struct {
+	ID                   uint                   `gorm:"primaryKey" json:"id"`
+	Name                 string                 `gorm:"type:varchar(255);uniqueIndex" json:"name"`
+	Description          string                 `json:"description"`
+	RRule                string                 `gorm:"type:varchar(255);not null" json:"rrule"`                   // Aturan pengulangan RFC 5545, mis. FREQ=WEEKLY;BYDAY=SA;COUNT=10
+	StartsAt             time.Time              `json:"starts_at"`                                                 // DTSTART: waktu mulai kemunculan pertama
+	DurationMinutes      int                    `json:"duration_minutes"`                                          // Durasi setiap kemunculan
+	Capacity             int                    `json:"capacity"`                                                  // Kapasitas per kemunculan
+	Price                money.Money            `gorm:"embedded;embeddedPrefix:price_" json:"price"`               // Harga tiket per kemunculan
+	Status               string                 `gorm:"type:varchar(50);default:'active'" json:"status"`           // Status awal kemunculan: draft, active
+	VenueID              *uint                  `gorm:"index" json:"venue_id"`                                     // Venue untuk semua kemunculan
+	ImageURL             string                 `gorm:"type:varchar(255)" json:"image_url"`                        // URL gambar untuk semua kemunculan
+	SalesOpenDaysBefore  int                    `gorm:"default:0" json:"sales_open_days_before"`                   // Penjualan dibuka X hari sebelum kemunculan, 0 berarti langsung dibuka
+	RefundFullDaysBefore int                    `gorm:"default:0" json:"refund_full_days_before"`                  // Lihat Event.RefundFullDaysBefore
+	RefundPartialPercent int                    `gorm:"default:0" json:"refund_partial_percent"`                   // Lihat Event.RefundPartialPercent
+	MinPerOrder          int                    `gorm:"default:0" json:"min_per_order"`                            // Lihat Event.MinPerOrder
+	MaxPerOrder          int                    `gorm:"default:0" json:"max_per_order"`                            // Lihat Event.MaxPerOrder
+	MaxPerUser           int                    `gorm:"default:0" json:"max_per_user"`                             // Lihat Event.MaxPerUser
+	TransferPolicy       string                 `gorm:"type:varchar(20);default:'allowed'" json:"transfer_policy"` // Lihat Event.TransferPolicy
+	TransferCutoffHours  int                    `gorm:"default:0" json:"transfer_cutoff_hours"`                    // Lihat Event.TransferCutoffHours
+	MaterializedUntil    *time.Time             `json:"materialized_until"`                                        // Kemunculan sampai waktu ini sudah dibuat
+	Exceptions           []EventSeriesException `gorm:"foreignKey:SeriesID" json:"exceptions,omitempty"`
+	Occurrences          []Event                `gorm:"foreignKey:SeriesID" json:"occurrences,omitempty"`
+	CreatedAt            time.Time              `gorm:"<-:create" json:"created_at"`
+	UpdatedAt            time.Time              `json:"updated_at"`
+}
+
+// EventSeriesException mengecualikan satu kemunculan (EXDATE) agar tidak dibuat atau dihapus dari seri
+type EventSeriesException struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	SeriesID        uint      `gorm:"uniqueIndex:idx_series_exception;not null" json:"series_id"`
+	OccurrenceStart time.Time `gorm:"uniqueIndex:idx_series_exception;not null" json:"occurrence_start"` // Waktu mulai kemunculan sesuai aturan
+	Reason          string    `gorm:"type:varchar(255)" json:"reason"`
+	CreatedAt       time.Time `gorm:"<-:create" json:"created_at"`
+}
+
+// NewOccurrence membuat event untuk satu kemunculan seri dari nilai template
+func (s EventSeries) NewOccurrence(start time.Time) Event {
+	occurrenceStart := start
+	event := Event{
+		Name:                 s.Name,
+		Description:          s.Description,
+		StartDate:            start,
+		EndDate:              start.Add(time.Duration(s.DurationMinutes) * time.Minute),
+		Capacity:             s.Capacity,
+		Price:                s.Price,
+		Status:               s.Status,
+		VenueID:              s.VenueID,
+		ImageURL:             s.ImageURL,
+		RefundFullDaysBefore: s.RefundFullDaysBefore,
+		RefundPartialPercent: s.RefundPartialPercent,
+		MinPerOrder:          s.MinPerOrder,
+		MaxPerOrder:          s.MaxPerOrder,
+		MaxPerUser:           s.MaxPerUser,
+		TransferPolicy:       s.TransferPolicy,
+		TransferCutoffHours:  s.TransferCutoffHours,
+		SeriesID:             &s.ID,
+		OccurrenceStart:      &occurrenceStart,
+	}
+	if s.SalesOpenDaysBefore > 0 {
+		salesStart := start.AddDate(0, 0, -s.SalesOpenDaysBefore)
+		event.SalesStartAt = &salesStart
+	}
+	return event
+}
+
+// SeriesOccurrenceStats adalah ringkasan penjualan satu kemunculan untuk laporan seri
+type SeriesOccurrenceStats struct {
+	EventID       uint        `json:"event_id"`
+	StartDate     time.Time   `json:"start_date"`
+	Status        string      `json:"status"`
+	Capacity      int         `json:"capacity"`
+	SoldCount     int         `json:"sold_count"`
+	ReservedCount int         `json:"reserved_count"`
+	TicketsSold   int64       `json:"tickets_sold"`
+	Revenue       money.Money `gorm:"embedded;embeddedPrefix:revenue_" json:"revenue"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"eventix/money"
+	"fmt"
+	"time"
+)
+
+// Invoice adalah bukti pembayaran order yang diterbitkan saat order lunas.
+// Nama event, harga dan data pembeli disalin agar invoice tidak berubah jika data aslinya diubah.
+type Invoice struct {
+	ID            uint          `gorm:"primaryKey" json:"id"`
+	Number        string        `gorm:"type:varchar(32);uniqueIndex;not null" json:"number"` // Format INV-<tahun>-<urutan>, berurutan tanpa celah per tahun
+	OrderID       uint          `gorm:"uniqueIndex;not null" json:"order_id"`
+	OrderCode     string        `gorm:"type:varchar(32)" json:"order_code"`
+	UserID        uint          `gorm:"index;not null" json:"user_id"`
+	BuyerName     string        `gorm:"type:varchar(255)" json:"buyer_name"`
+	BuyerEmail    string        `gorm:"type:varchar(255)" json:"buyer_email"` // Email pemegang kursi pertama, jika diisi
+	PromoCode     string        `gorm:"type:varchar(50)" json:"promo_code"`
+	Subtotal      money.Money   `gorm:"embedded;embeddedPrefix:subtotal_" json:"subtotal"` // Harga tiket sebelum diskon
+	Discount      money.Money   `gorm:"embedded;embeddedPrefix:discount_" json:"discount"`
+	ServiceFee    money.Money   `gorm:"embedded;embeddedPrefix:service_fee_" json:"service_fee"`
+	TaxableAmount money.Money   `gorm:"embedded;embeddedPrefix:taxable_" json:"taxable_amount"` // Subtotal setelah diskon ditambah biaya layanan
+	TaxPercent    float64       `json:"tax_percent"`
+	Tax           money.Money   `gorm:"embedded;embeddedPrefix:tax_" json:"tax"`
+	Total         money.Money   `gorm:"embedded;embeddedPrefix:total_" json:"total"`
+	IssuedAt      time.Time     `json:"issued_at"`
+	Lines         []InvoiceLine `gorm:"foreignKey:InvoiceID" json:"lines"`
+	CreatedAt     time.Time     `gorm:"<-:create" json:"created_at"`
+}
+
+// InvoiceLine adalah satu baris invoice, satu per tiket di dalam order
+type InvoiceLine struct {
+	ID          uint        `gorm:"primaryKey" json:"id"`
+	InvoiceID   uint        `gorm:"index;not null" json:"invoice_id"`
+	TicketID    uint        `gorm:"index;not null" json:"ticket_id"`
+	Description string      `gorm:"type:varchar(255)" json:"description"` // Nama event dan kategori tiket
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `gorm:"embedded;embeddedPrefix:unit_price_" json:"unit_price"`
+	Discount    money.Money `gorm:"embedded;embeddedPrefix:discount_" json:"discount"`
+	Total       money.Money `gorm:"embedded;embeddedPrefix:total_" json:"total"` // Harga baris setelah diskon
+}
+
+// InvoiceSequence menyimpan nomor invoice terakhir per tahun
+type InvoiceSequence struct {
+	Year       int `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int `gorm:"not null;default:0"`
+}
+
+// InvoiceNumber membentuk nomor invoice, mis. INV-2026-000042
+func InvoiceNumber(year int, sequence int) string {
+	return fmt.Sprintf("INV-%d-%06d", year, sequence)
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// Notification adalah outbox pesan untuk user; dikirim oleh worker terpisah lalu ditandai sent
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	Type      string     `gorm:"type:varchar(50);index" json:"type"`
+	Subject   string     `gorm:"type:varchar(255)" json:"subject"`
+	Body      string     `gorm:"type:text" json:"body"`
+	Status    string     `gorm:"type:varchar(20);index;default:'pending'" json:"status"` // Status: pending, sent
+	SentAt    *time.Time `json:"sent_at"`
+	CreatedAt time.Time  `gorm:"<-:create" json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"eventix/money"
+	"time"
+)
+
+// Order mengelompokkan tiket dari satu kali checkout (bisa lintas event dan kategori) dalam satu pembayaran
+type Order struct {
+	ID                  uint        `gorm:"primaryKey" json:"id"`
+	UserID              uint        `gorm:"index;not null" json:"user_id"`
+	Code                string      `gorm:"type:varchar(32);uniqueIndex" json:"code"`
+	Status              string      `gorm:"type:varchar(20);index" json:"status"`                    // Status: pending_payment, paid, payment_failed
+	Quantity            int         `json:"quantity"`                                                // Jumlah kursi di seluruh tiket
+	Subtotal            money.Money `gorm:"embedded;embeddedPrefix:subtotal_" json:"subtotal"`       // Jumlah harga tiket sebelum diskon
+	Discount            money.Money `gorm:"embedded;embeddedPrefix:discount_" json:"discount"`       // Potongan dari kode promo
+	PromoCodeID         *uint       `gorm:"index" json:"promo_code_id"`                              // Kode promo yang dipakai
+	ServiceFee          money.Money `gorm:"embedded;embeddedPrefix:service_fee_" json:"service_fee"` // Biaya layanan per kursi
+	TaxPercent          float64     `json:"tax_percent"`                                             // Tarif pajak yang berlaku saat order dibuat
+	Tax                 money.Money `gorm:"embedded;embeddedPrefix:tax_" json:"tax"`                 // Pajak atas subtotal setelah diskon dan biaya layanan
+	Total               money.Money `gorm:"embedded;embeddedPrefix:total_" json:"total"`             // Jumlah yang ditagihkan
+	PaymentIntentID     string      `gorm:"type:varchar(100);index" json:"payment_intent_id"`
+	PaymentDueAt        *time.Time  `gorm:"index" json:"payment_due_at"`                 // Batas pembayaran; setelahnya kursi dilepas
+	PaymentClientSecret string      `gorm:"-" json:"payment_client_secret,omitempty"`    // Hanya dikirim saat checkout
+	Tickets             []Ticket    `gorm:"foreignKey:OrderID" json:"tickets,omitempty"` // Satu tiket per baris pesanan
+	CreatedAt           time.Time   `gorm:"<-:create" json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+}
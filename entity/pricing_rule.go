@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"eventix/money"
+	"time"
+)
+
+// PricingRule mengubah harga tiket event berdasarkan waktu atau jumlah kursi yang sudah terjual.
+// Jika beberapa aturan berlaku sekaligus, hanya satu yang dipakai (lihat package pricing).
+type PricingRule struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	EventID          uint        `gorm:"index;not null" json:"event_id"`
+	TicketTypeID     *uint       `gorm:"index" json:"ticket_type_id"` // Kosong berarti berlaku untuk harga event dan semua kategori
+	Name             string      `gorm:"type:varchar(100)" json:"name"`
+	RuleType         string      `gorm:"type:varchar(20);not null" json:"rule_type"`        // Jenis: early_bird, sold_threshold, last_minute
+	AdjustmentType   string      `gorm:"type:varchar(20);not null" json:"adjustment_type"`  // Jenis: percentage, amount, price
+	Value            float64     `json:"value"`                                             // percentage: persen penyesuaian (negatif = potongan)
+	Amount           money.Money `gorm:"embedded;embeddedPrefix:adjustment_" json:"amount"` // amount: nominal penyesuaian (negatif = potongan); price: harga satuan baru
+	ValidUntil       *time.Time  `json:"valid_until"`                                       // early_bird: berlaku sebelum waktu ini
+	SoldThreshold    int         `gorm:"default:0" json:"sold_threshold"`                   // sold_threshold: berlaku setelah kursi terjual mencapai angka ini
+	HoursBeforeStart int         `gorm:"default:0" json:"hours_before_start"`               // last_minute: berlaku mulai sekian jam sebelum event
+	Priority         int         `gorm:"default:0" json:"priority"`                         // Aturan dengan prioritas lebih tinggi menang
+	IsActive         bool        `gorm:"default:true" json:"is_active"`
+	CreatedAt        time.Time   `gorm:"<-:create" json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// PriceQuote adalah hasil perhitungan harga untuk sejumlah kursi
+type PriceQuote struct {
+	EventID      uint         `json:"event_id"`
+	TicketTypeID *uint        `json:"ticket_type_id"`
+	Quantity     int          `json:"quantity"`
+	BasePrice    money.Money  `json:"base_price"` // Harga satuan sebelum aturan harga
+	UnitPrice    money.Money  `json:"unit_price"` // Harga satuan setelah aturan harga
+	Total        money.Money  `json:"total"`
+	Rule         *PricingRule `json:"rule"` // Aturan yang menghasilkan harga; kosong jika memakai harga dasar
+}
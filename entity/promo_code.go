@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"eventix/money"
+	"strings"
+	"time"
+)
+
+// PromoCode adalah kode diskon yang dikelola admin dan dipakai saat pembelian
+type PromoCode struct {
+	ID             uint        `gorm:"primaryKey" json:"id"`
+	Code           string      `gorm:"type:varchar(50);uniqueIndex;not null" json:"code"` // Disimpan dalam huruf besar
+	Description    string      `json:"description"`
+	DiscountType   string      `gorm:"type:varchar(20);not null" json:"discount_type"`           // Jenis: percentage, fixed, buy_x_get_y
+	Value          float64     `json:"value"`                                                    // Persen potongan (percentage)
+	DiscountAmount money.Money `gorm:"embedded;embeddedPrefix:discount_" json:"discount_amount"` // Nominal potongan per order (fixed); hanya untuk tiket bermata uang sama
+	BuyQuantity    int         `gorm:"default:0" json:"buy_quantity"`                            // buy_x_get_y: jumlah kursi yang dibayar
+	GetQuantity    int         `gorm:"default:0" json:"get_quantity"`                            // buy_x_get_y: jumlah kursi gratis
+	EventID        *uint       `gorm:"index" json:"event_id"`                                    // Kosong berarti berlaku untuk semua event
+	TicketTypeID   *uint       `gorm:"index" json:"ticket_type_id"`                              // Kosong berarti berlaku untuk semua kategori
+	StartsAt       *time.Time  `json:"starts_at"`
+	EndsAt         *time.Time  `json:"ends_at"`
+	MaxUses        int         `gorm:"default:0" json:"max_uses"`          // 0 berarti tanpa batas
+	MaxUsesPerUser int         `gorm:"default:0" json:"max_uses_per_user"` // 0 berarti tanpa batas
+	IsActive       bool        `gorm:"default:true" json:"is_active"`
+	CreatedAt      time.Time   `gorm:"<-:create" json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// NormalizePromoCode menyamakan format kode yang diketik user dengan yang disimpan
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidAt memeriksa apakah kode aktif dan waktu now berada di dalam masa berlakunya
+func (p PromoCode) IsValidAt(now time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && now.After(*p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// AppliesTo memeriksa apakah tiket untuk event dan kategori tersebut termasuk cakupan kode
+func (p PromoCode) AppliesTo(eventID uint, ticketTypeID *uint) bool {
+	if p.EventID != nil && *p.EventID != eventID {
+		return false
+	}
+	if p.TicketTypeID != nil && (ticketTypeID == nil || *p.TicketTypeID != *ticketTypeID) {
+		return false
+	}
+	return true
+}
+
+// PromoRedemption mencatat satu pemakaian kode promo pada satu order
+type PromoRedemption struct {
+	ID          uint        `gorm:"primaryKey" json:"id"`
+	PromoCodeID uint        `gorm:"index;not null" json:"promo_code_id"`
+	UserID      uint        `gorm:"index;not null" json:"user_id"`
+	OrderID     uint        `gorm:"uniqueIndex;not null" json:"order_id"`
+	Discount    money.Money `gorm:"embedded;embeddedPrefix:discount_" json:"discount"` // Total potongan di seluruh tiket order
+	CreatedAt   time.Time   `gorm:"<-:create" json:"created_at"`
+}
+
+// PromoRedemptionStats adalah ringkasan pemakaian satu kode promo untuk laporan, satu baris per mata uang order
+type PromoRedemptionStats struct {
+	PromoCodeID   uint        `json:"promo_code_id"`
+	Code          string      `json:"code"`
+	DiscountType  string      `json:"discount_type"`
+	Redemptions   int64       `json:"redemptions"`    // Pemakaian pada order yang sudah dibayar
+	PendingOrders int64       `json:"pending_orders"` // Pemakaian yang masih menunggu pembayaran
+	UniqueUsers   int64       `json:"unique_users"`
+	TotalDiscount money.Money `gorm:"embedded;embeddedPrefix:total_discount_" json:"total_discount"`
+	Revenue       money.Money `gorm:"embedded;embeddedPrefix:revenue_" json:"revenue"` // Total tagihan order yang sudah dibayar
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"eventix/money"
+	"fmt"
+	"time"
+)
+
+// Refund mencatat pengembalian dana untuk tiket yang dibatalkan.
+// Baris dibuat pending bersama pembatalan tiket, lalu dikirim ke provider setelah transaksi selesai.
+type Refund struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	TicketID         uint        `gorm:"index;not null" json:"ticket_id"`
+	TicketItemID     *uint       `json:"ticket_item_id"` // Terisi jika hanya satu kursi yang dibatalkan
+	UserID           uint        `gorm:"index;not null" json:"user_id"`
+	Amount           money.Money `gorm:"embedded;embeddedPrefix:refund_" json:"amount"`
+	Percent          int         `json:"percent"`
+	Reason           string      `gorm:"type:varchar(255)" json:"reason"`
+	Status           string      `gorm:"type:varchar(20);index;not null;default:'succeeded'" json:"status"` // Status: pending, succeeded, failed; refund lama sudah diproses saat dibuat
+	PaymentIntentID  string      `gorm:"type:varchar(100)" json:"-"`                                        // Intent yang dananya dikembalikan
+	Attempts         int         `gorm:"not null;default:0" json:"attempts"`                                // Jumlah pengiriman ke provider
+	FailureReason    string      `gorm:"type:varchar(255)" json:"failure_reason,omitempty"`
+	ProviderRefundID string      `gorm:"type:varchar(100)" json:"provider_refund_id"`
+	CreatedAt        time.Time   `gorm:"<-:create" json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// IdempotencyKey dikirim ke provider agar refund yang dikirim ulang tidak mengembalikan dana dua kali
+func (r Refund) IdempotencyKey() string {
+	return fmt.Sprintf("refund-%d", r.ID)
+}
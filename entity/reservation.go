@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Reservation menahan sejumlah kursi untuk user selama pembayaran berlangsung
+type Reservation struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	EventID      uint      `gorm:"index;not null" json:"event_id"`
+	TicketTypeID *uint     `gorm:"index" json:"ticket_type_id"`
+	UserID       uint      `gorm:"index;not null" json:"user_id"`
+	Quantity     int       `json:"quantity"`
+	Status       string    `gorm:"type:varchar(20);index" json:"status"` // Status: held, confirmed, expired, cancelled
+	ExpiresAt    time.Time `gorm:"index" json:"expires_at"`
+	TicketID     *uint     `json:"ticket_id"` // Tiket yang dibuat saat reservasi dikonfirmasi
+	CreatedAt    time.Time `gorm:"<-:create" json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// SchedulerLock adalah lock leader di database agar job terjadwal hanya berjalan di satu replika
+type SchedulerLock struct {
+	Name      string    `gorm:"primaryKey;type:varchar(100)"`
+	Owner     string    `gorm:"type:varchar(255);not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,9 @@
+package entity
+
+import "time"
+
+// SchemaMigration mencatat migrasi data yang sudah dijalankan agar tidak diulang
+type SchemaMigration struct {
+	ID        string    `gorm:"primaryKey;type:varchar(100)"`
+	AppliedAt time.Time `gorm:"not null"`
+}
@@ -1,14 +1,31 @@
 package entity
 
-import "time"
+import (
+	"eventix/money"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Ticket struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	EventID   uint      `json:"event_id"`
-	UserID    uint      `json:"user_id"`
-	Quantity  int       `json:"quantity"` // Tambahkan field Quantity
-	Price     float64   `json:"price"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                  uint           `gorm:"primaryKey" json:"id"`
+	EventID             uint           `json:"event_id"`
+	TicketTypeID        *uint          `gorm:"index" json:"ticket_type_id"`
+	UserID              uint           `json:"user_id"`
+	OrderID             *uint          `gorm:"index" json:"order_id"`                             // Order asal pembelian
+	Code                string         `gorm:"type:varchar(32);uniqueIndex" json:"code"`          // Kode pemesanan; memindainya meng-check-in semua kursi aktif sekaligus
+	Quantity            int            `json:"quantity"`                                          // Tambahkan field Quantity
+	Price               money.Money    `gorm:"embedded;embeddedPrefix:price_" json:"price"`       // Harga setelah diskon
+	Discount            money.Money    `gorm:"embedded;embeddedPrefix:discount_" json:"discount"` // Potongan dari kode promo
+	PromoCodeID         *uint          `gorm:"index" json:"promo_code_id"`                        // Kode promo yang memberi potongan
+	PricingRuleID       *uint          `gorm:"index" json:"pricing_rule_id"`                      // Aturan harga dinamis yang menentukan harga satuan
+	PromoCode           string         `gorm:"-" json:"promo_code,omitempty"`                     // Kode promo yang dimasukkan saat pembelian
+	Status              string         `json:"status"`                                            // Status: pending_payment, purchased, payment_failed, cancelled
+	PaymentIntentID     string         `gorm:"type:varchar(100);index" json:"payment_intent_id"`
+	PaymentClientSecret string         `gorm:"-" json:"payment_client_secret,omitempty"` // Hanya dikirim saat pembelian dibuat
+	SeatIDs             []uint         `gorm:"-" json:"seat_ids,omitempty"`              // Pilihan kursi saat pembelian; jumlahnya menjadi Quantity
+	Items               []TicketItem   `gorm:"foreignKey:TicketID" json:"items"`         // Satu item per kursi (Quantity)
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
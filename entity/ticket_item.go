@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// TicketItem adalah satu kursi di dalam tiket, dengan pemegang dan kode verifikasinya sendiri
+type TicketItem struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	TicketID    uint       `gorm:"index;not null" json:"ticket_id"`
+	EventID     uint       `gorm:"index;not null" json:"event_id"`
+	SeatID      *uint      `gorm:"index" json:"seat_id"` // Kursi yang dipilih pada event dengan denah kursi
+	HolderName  string     `gorm:"type:varchar(255)" json:"holder_name"`
+	HolderEmail string     `gorm:"type:varchar(255)" json:"holder_email"`
+	Code        string     `gorm:"type:varchar(32);uniqueIndex" json:"code"`
+	Status      string     `gorm:"type:varchar(20);default:'active'" json:"status"` // Status: active, cancelled
+	CheckedInAt *time.Time `json:"checked_in_at"`
+	CheckedInBy *uint      `json:"checked_in_by"` // User staff yang memindai kursi
+	GateID      string     `gorm:"type:varchar(50)" json:"gate_id"`
+	CreatedAt   time.Time  `gorm:"<-:create" json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// TicketTransfer mencatat permintaan pemindahan kepemilikan tiket dan menjadi jejak audit pemilik sebelumnya
+type TicketTransfer struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	TicketID     uint       `gorm:"index;not null" json:"ticket_id"`
+	TicketItemID *uint      `gorm:"index" json:"ticket_item_id"` // Diisi bila hanya satu kursi yang dipindahkan
+	ToTicketID   *uint      `gorm:"index" json:"to_ticket_id"`   // Tiket baru milik penerima untuk kursi yang dipindahkan
+	FromUserID   uint       `gorm:"index;not null" json:"from_user_id"`
+	ToUserID     uint       `gorm:"index;not null" json:"to_user_id"`
+	Status       string     `gorm:"type:varchar(20);index" json:"status"` // Status: pending, accepted, declined, cancelled
+	RespondedAt  *time.Time `json:"responded_at"`
+	CreatedAt    time.Time  `gorm:"<-:create" json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
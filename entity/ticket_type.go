@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"eventix/money"
+	"time"
+)
+
+// TicketType adalah kategori harga dalam satu event (mis. VIP, Regular, Early Bird)
+type TicketType struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	EventID       uint        `gorm:"index;not null" json:"event_id"`
+	Name          string      `gorm:"type:varchar(100);not null" json:"name"`
+	Price         money.Money `gorm:"embedded;embeddedPrefix:price_" json:"price"` // Harus bermata uang sama dengan event
+	Quota         int         `json:"quota"`
+	SoldCount     int         `gorm:"default:0" json:"sold_count"`
+	ReservedCount int         `gorm:"default:0" json:"reserved_count"`
+	MaxPerOrder   int         `gorm:"default:0" json:"max_per_order"` // 0 berarti tanpa batas
+	SalesStartAt  *time.Time  `json:"sales_start_at"`
+	SalesEndAt    *time.Time  `json:"sales_end_at"`
+	CreatedAt     time.Time   `gorm:"<-:create" json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// AvailableQuota menghitung sisa kuota kategori yang masih bisa dijual
+func (t TicketType) AvailableQuota() int {
+	return t.Quota - t.SoldCount - t.ReservedCount
+}
+
+// IsOnSale memeriksa apakah waktu now berada di dalam jendela penjualan kategori
+func (t TicketType) IsOnSale(now time.Time) bool {
+	if t.SalesStartAt != nil && now.Before(*t.SalesStartAt) {
+		return false
+	}
+	if t.SalesEndAt != nil && now.After(*t.SalesEndAt) {
+		return false
+	}
+	return true
+}
@@ -1,8 +1,11 @@
 package entity
 
+import "gorm.io/gorm"
+
 type User struct {
-    ID       uint   `gorm:"primaryKey"`
-    Username string `gorm:"unique"`
-    Password string
-    Role     string // Admin, User, etc.
+    ID        uint           `gorm:"primaryKey"`
+    Username  string         `gorm:"unique"`
+    Password  string
+    Role      string         // Admin, User, etc.
+    DeletedAt gorm.DeletedAt `gorm:"index"` // Soft delete; user terhapus tidak bisa login
 }
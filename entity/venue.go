@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Venue adalah lokasi event beserta denah kursinya (section → row → seat)
+type Venue struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
+	Address   string    `gorm:"type:varchar(255)" json:"address"`
+	City      string    `gorm:"type:varchar(100)" json:"city"`
+	Sections  []Section `gorm:"foreignKey:VenueID" json:"sections,omitempty"`
+	CreatedAt time.Time `gorm:"<-:create" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Section adalah area di dalam venue, mis. "Tribun Utara" atau "VIP"
+type Section struct {
+	ID      uint      `gorm:"primaryKey" json:"id"`
+	VenueID uint      `gorm:"index;not null" json:"venue_id"`
+	Name    string    `gorm:"type:varchar(100);not null" json:"name"`
+	Rows    []SeatRow `gorm:"foreignKey:SectionID" json:"rows,omitempty"`
+}
+
+// SeatRow adalah satu baris kursi di dalam section
+type SeatRow struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	SectionID uint   `gorm:"index;not null" json:"section_id"`
+	Label     string `gorm:"type:varchar(20);not null" json:"label"`
+	Seats     []Seat `gorm:"foreignKey:RowID" json:"seats,omitempty"`
+}
+
+// Seat adalah satu kursi fisik di venue; VenueID disimpan agar validasi pilihan kursi tidak perlu join
+type Seat struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	VenueID   uint   `gorm:"index;not null" json:"venue_id"`
+	SectionID uint   `gorm:"index;not null" json:"section_id"`
+	RowID     uint   `gorm:"index;not null" json:"row_id"`
+	Number    string `gorm:"type:varchar(20);not null" json:"number"`
+	Status    string `gorm:"-" json:"status,omitempty"` // Ketersediaan untuk event tertentu: available, taken
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// WaitlistEntry mengantrekan user untuk event yang sudah habis; antrean dilayani FIFO berdasarkan ID
+type WaitlistEntry struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	EventID       uint       `gorm:"index:idx_waitlist_event_status;not null" json:"event_id"`
+	TicketTypeID  *uint      `gorm:"index" json:"ticket_type_id"`
+	UserID        uint       `gorm:"index;not null" json:"user_id"`
+	Quantity      int        `json:"quantity"`
+	Status        string     `gorm:"type:varchar(20);index:idx_waitlist_event_status" json:"status"` // Status: waiting, offered, fulfilled, expired, left
+	ReservationID *uint      `gorm:"index" json:"reservation_id"`                                    // Reservasi yang ditawarkan saat antrean dipromosikan
+	OfferedAt     *time.Time `json:"offered_at"`
+	Position      int64      `gorm:"-" json:"position,omitempty"` // Posisi dalam antrean, dihitung saat dibaca
+	CreatedAt     time.Time  `gorm:"<-:create" json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"eventix/clock"
 	"eventix/config"
 	"eventix/controller"
 	_ "eventix/docs" // Import Swagger docs
 	"eventix/middleware"
+	"eventix/money"
+	"eventix/payment"
 	"eventix/repository"
+	"eventix/scheduler"
 	"eventix/service"
+	"eventix/ticketcode"
 	"log"
 	"time"
 
@@ -27,20 +32,109 @@ func main() {
 	// Inisialisasi database
 	db := config.DBInit()
 
+	// Mata uang default untuk harga yang dikirim tanpa mata uang
+	money.DefaultCurrency = config.DefaultCurrency()
+
 	// Dependency Injection
+	txManager := repository.NewTxManager(db)
+
 	userRepo := repository.NewUserRepository(db)
 	userService := service.NewUserService(userRepo)
 	userController := controller.NewUserController(userService)
 
 	eventRepo := repository.NewEventRepository(db)
 	ticketRepo := repository.NewTicketRepository(db)
-	eventService := service.NewEventService(eventRepo, ticketRepo)
+	venueRepo := repository.NewVenueRepository(db)
+	ticketTypeRepo := repository.NewTicketTypeRepository(db)
+	pricingRuleRepo := repository.NewPricingRuleRepository(db)
+	eventService := service.NewEventService(txManager, eventRepo, ticketRepo, ticketTypeRepo, pricingRuleRepo, venueRepo)
 	eventController := controller.NewEventController(eventService)
+	eventLifecycleService := service.NewEventLifecycleService(eventRepo, clock.System())
+
+	// Payment gateway wajib dipilih eksplisit; gateway lokal (fake) tidak pernah menjadi default
+	var paymentProvider payment.Provider
+	var fakePaymentProvider *payment.FakeProvider
+	switch providerName := config.GetEnv("PAYMENT_PROVIDER", ""); providerName {
+	case "":
+		log.Fatal("PAYMENT_PROVIDER must be set")
+	case "fake":
+		fakePaymentProvider = payment.NewFakeProvider(config.GetEnv("PAYMENT_WEBHOOK_SECRET", "your_webhook_secret"))
+		paymentProvider = fakePaymentProvider
+	default:
+		log.Fatalf("Unknown payment provider: %s", providerName)
+	}
+	// Simulasi pembayaran hanya untuk development: siapa pun yang memanggilnya bisa melunasi order tanpa membayar
+	fakePaymentSimulation := fakePaymentProvider != nil && config.GetEnvBool("PAYMENT_FAKE_SIMULATION", false)
+	orderRepo := repository.NewOrderRepository(db)
+	refundRepo := repository.NewRefundRepository(db)
+	paymentTTL := config.GetEnvDuration("ORDER_PAYMENT_TTL", 30*time.Minute)
+	paymentService := service.NewPaymentService(txManager, orderRepo, refundRepo, paymentProvider, paymentTTL)
+	paymentController := controller.NewPaymentController(paymentService, fakePaymentProvider)
 
-	ticketService := service.NewTicketService(ticketRepo, eventRepo)
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	waitlistOfferTTL := config.GetEnvDuration("WAITLIST_OFFER_TTL", 30*time.Minute)
+	waitlistService := service.NewWaitlistService(txManager, waitlistRepo, waitlistOfferTTL)
+	waitlistController := controller.NewWaitlistController(waitlistService)
+
+	// Biaya layanan dan pajak untuk setiap order
+	orderPricing := service.OrderPricing{
+		ServiceFeePerTicket: config.GetEnvFloat("ORDER_SERVICE_FEE_PER_TICKET", 0),
+		TaxPercent:          config.GetEnvFloat("ORDER_TAX_PERCENT", 0),
+	}
+
+	ticketSigner := ticketcode.NewSigner(config.GetEnv("TICKET_SIGNING_KEY", "your_ticket_signing_key"))
+	ticketService := service.NewTicketService(txManager, ticketRepo, eventRepo, paymentService, waitlistService, ticketSigner, orderPricing)
 	ticketController := controller.NewTicketController(ticketService)
 
-	reportService := service.NewReportService(ticketRepo)
+	invoiceRepo := repository.NewInvoiceRepository(db)
+	invoiceService := service.NewInvoiceService(invoiceRepo, ticketRepo, eventRepo, ticketTypeRepo, venueRepo, ticketSigner)
+	invoiceController := controller.NewInvoiceController(invoiceService)
+
+	ticketTypeService := service.NewTicketTypeService(txManager, ticketTypeRepo, eventRepo)
+	ticketTypeController := controller.NewTicketTypeController(ticketTypeService)
+
+	ticketItemRepo := repository.NewTicketItemRepository(db)
+	checkInService := service.NewCheckInService(txManager, ticketItemRepo, eventRepo, ticketSigner)
+	checkInController := controller.NewCheckInController(checkInService)
+
+	transferRepo := repository.NewTicketTransferRepository(db)
+	transferService := service.NewTransferService(txManager, transferRepo, ticketRepo)
+	transferController := controller.NewTransferController(transferService)
+
+	eventSeatRepo := repository.NewEventSeatRepository(db)
+	venueService := service.NewVenueService(txManager, venueRepo, eventRepo, eventSeatRepo)
+	venueController := controller.NewVenueController(venueService)
+
+	pricingRuleService := service.NewPricingRuleService(pricingRuleRepo, eventRepo, ticketTypeRepo)
+	pricingRuleController := controller.NewPricingRuleController(pricingRuleService)
+
+	cartRepo := repository.NewCartRepository(db)
+	orderService := service.NewOrderService(txManager, orderRepo, cartRepo, eventRepo, ticketTypeRepo, pricingRuleRepo, paymentService, orderPricing)
+	orderController := controller.NewOrderController(orderService)
+
+	promoRepo := repository.NewPromoCodeRepository(db)
+	promoService := service.NewPromoCodeService(promoRepo, eventRepo, ticketTypeRepo)
+	promoController := controller.NewPromoCodeController(promoService)
+
+	reservationRepo := repository.NewReservationRepository(db)
+	reservationTTL := config.GetEnvDuration("RESERVATION_TTL", 15*time.Minute)
+	reservationService := service.NewReservationService(txManager, reservationRepo, paymentService, reservationTTL, orderPricing)
+	reservationController := controller.NewReservationController(reservationService)
+
+	cancellationRepo := repository.NewEventCancellationRepository(db)
+	cancellationService := service.NewEventCancellationService(txManager, cancellationRepo, paymentService)
+	cancellationController := controller.NewEventCancellationController(cancellationService)
+
+	softDeleteRetention := config.GetEnvDuration("SOFT_DELETE_RETENTION", 30*24*time.Hour)
+	trashService := service.NewTrashService(txManager, eventRepo, ticketRepo, userRepo, softDeleteRetention)
+	trashController := controller.NewTrashController(trashService)
+
+	seriesRepo := repository.NewEventSeriesRepository(db)
+	seriesHorizon := config.GetEnvDuration("SERIES_HORIZON", 90*24*time.Hour)
+	seriesService := service.NewEventSeriesService(txManager, seriesRepo, eventRepo, venueRepo, seriesHorizon)
+	seriesController := controller.NewEventSeriesController(seriesService)
+
+	reportService := service.NewReportService(ticketRepo, eventRepo, seriesRepo, promoRepo)
 	reportController := controller.NewReportController(reportService)
 
 	blacklistRepo := repository.NewTokenBlacklistRepository(db)
@@ -49,6 +143,89 @@ func main() {
 
 	exportController := controller.NewExportController(reportService)
 
+	// Lock leader agar job terjadwal berikut hanya dijalankan oleh satu replika
+	schedulerLockRepo := repository.NewSchedulerLockRepository(db)
+
+	// Background job: lepaskan reservasi yang kedaluwarsa
+	stopReservationSweeper := scheduler.Every("reservation-sweeper", time.Minute, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "reservation-sweeper", scheduler.InstanceID(), 3*time.Minute,
+		func() error {
+			_, err := reservationService.ReleaseExpiredReservations()
+			return err
+		},
+	))
+	defer stopReservationSweeper()
+
+	// Background job: lepaskan kursi order yang tidak dibayar sampai batas waktunya
+	stopPaymentExpiry := scheduler.Every("payment-expiry", time.Minute, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "payment-expiry", scheduler.InstanceID(), 3*time.Minute,
+		func() error {
+			_, err := paymentService.ExpireOverdueOrders()
+			return err
+		},
+	))
+	defer stopPaymentExpiry()
+
+	// Background job: kirim ulang refund yang gagal atau tertahan di provider
+	stopRefundRetry := scheduler.Every("refund-retry", time.Minute, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "refund-retry", scheduler.InstanceID(), 3*time.Minute,
+		func() error {
+			_, err := paymentService.RetryRefunds()
+			return err
+		},
+	))
+	defer stopRefundRetry()
+
+	// Background job: ubah status event sesuai jadwal
+	stopEventLifecycle := scheduler.Every("event-lifecycle", time.Minute, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "event-lifecycle", scheduler.InstanceID(), 3*time.Minute,
+		func() error {
+			_, err := eventLifecycleService.AdvanceEventStatuses()
+			return err
+		},
+	))
+	defer stopEventLifecycle()
+
+	// Background job: lanjutkan pembatalan event yang terputus (mis. server restart)
+	stopEventCancellation := scheduler.Every("event-cancellation", time.Minute, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "event-cancellation", scheduler.InstanceID(), 3*time.Minute,
+		func() error {
+			_, err := cancellationService.ProcessPendingCancellations()
+			return err
+		},
+	))
+	defer stopEventCancellation()
+
+	// Background job: hapus permanen data soft delete yang melewati masa retensi
+	stopTrashPurge := scheduler.Every("trash-purge", time.Hour, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "trash-purge", scheduler.InstanceID(), 2*time.Hour,
+		func() error {
+			_, err := trashService.PurgeExpired()
+			return err
+		},
+	))
+	defer stopTrashPurge()
+
+	// Background job: buat kemunculan seri event berulang sampai batas horizon
+	stopSeriesMaterializer := scheduler.Every("series-materializer", time.Hour, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "series-materializer", scheduler.InstanceID(), 2*time.Hour,
+		func() error {
+			_, err := seriesService.MaterializeAllSeries()
+			return err
+		},
+	))
+	defer stopSeriesMaterializer()
+
+	// Background job: tawarkan kursi yang dilepas ke antrean waitlist
+	stopWaitlistPromoter := scheduler.Every("waitlist-promoter", time.Minute, scheduler.WithLeaderLock(
+		schedulerLockRepo, clock.System(), "waitlist-promoter", scheduler.InstanceID(), 3*time.Minute,
+		func() error {
+			_, err := waitlistService.PromoteAllWaitlists()
+			return err
+		},
+	))
+	defer stopWaitlistPromoter()
+
 	// Setup Router
 	r := gin.Default()
 
@@ -58,6 +235,7 @@ func main() {
 	// Route publik tanpa middleware
 	r.POST("/users/register", userController.RegisterUser)
 	r.POST("/login", authController.Login)
+	r.POST("/payments/webhook", paymentController.HandleWebhook)
 
 	// Middleware untuk autentikasi
 	r.Use(middleware.AuthenticationMiddleware("your_secret_key"))
@@ -68,9 +246,43 @@ func main() {
 	// Routes untuk pengguna umum (User)
 	r.GET("/events", middleware.AuthorizeRole("User"), eventController.GetAllEvents)
 	r.GET("/events/:id", middleware.AuthorizeRole("User"), eventController.GetEventByID)
+	r.GET("/events/:id/ticket-types", middleware.AuthorizeRole("User"), ticketTypeController.GetTicketTypes)
+	r.GET("/events/:id/seats", middleware.AuthorizeRole("User"), venueController.GetEventSeats)
+	r.GET("/events/:id/price", middleware.AuthorizeRole("User"), pricingRuleController.GetEventPrice)
+	r.POST("/events/:id/waitlist", middleware.AuthorizeRole("User"), waitlistController.JoinWaitlist)
+	r.GET("/events/:id/waitlist", middleware.AuthorizeRole("User"), waitlistController.GetWaitlistEntry)
+	r.DELETE("/events/:id/waitlist", middleware.AuthorizeRole("User"), waitlistController.LeaveWaitlist)
 	r.GET("/tickets", middleware.AuthorizeRole("User"), ticketController.GetTickets)
 	r.POST("/tickets", middleware.AuthorizeRole("User"), ticketController.CreateTicket)
-	r.PATCH("/tickets/:id", middleware.AuthorizeRole("User"), ticketController.CancelTicket)
+	r.GET("/tickets/:id", middleware.AuthorizeRole("User", "Admin"), ticketController.GetTicketByID)
+	r.PATCH("/tickets/:id", middleware.AuthorizeRole("User", "Admin"), ticketController.CancelTicket)
+	r.GET("/tickets/:id/qr", middleware.AuthorizeRole("User", "Admin"), ticketController.GetTicketQR)
+	r.GET("/tickets/:id/receipt.pdf", middleware.AuthorizeRole("User", "Admin"), invoiceController.GetTicketReceipt)
+	r.PATCH("/tickets/:id/items/:item_id", middleware.AuthorizeRole("User", "Admin"), ticketController.CancelTicketItem)
+	r.PUT("/tickets/:id/items/:item_id", middleware.AuthorizeRole("User", "Admin"), ticketController.UpdateTicketItemHolder)
+	r.GET("/tickets/:id/items/:item_id/qr", middleware.AuthorizeRole("User", "Admin"), ticketController.GetTicketItemQR)
+	r.POST("/tickets/:id/transfer", middleware.AuthorizeRole("User"), transferController.RequestTransfer)
+	r.POST("/tickets/:id/items/:item_id/transfer", middleware.AuthorizeRole("User"), transferController.RequestItemTransfer)
+	r.GET("/tickets/:id/transfers", middleware.AuthorizeRole("User", "Admin"), transferController.GetTicketTransfers)
+	r.GET("/transfers", middleware.AuthorizeRole("User"), transferController.GetPendingTransfers)
+	r.POST("/transfers/:id/accept", middleware.AuthorizeRole("User"), transferController.AcceptTransfer)
+	r.POST("/transfers/:id/decline", middleware.AuthorizeRole("User"), transferController.DeclineTransfer)
+	r.POST("/transfers/:id/cancel", middleware.AuthorizeRole("User"), transferController.CancelTransfer)
+	r.GET("/cart", middleware.AuthorizeRole("User"), orderController.GetCart)
+	r.POST("/cart/items", middleware.AuthorizeRole("User"), orderController.AddCartItem)
+	r.DELETE("/cart/items/:id", middleware.AuthorizeRole("User"), orderController.RemoveCartItem)
+	r.POST("/checkout", middleware.AuthorizeRole("User"), orderController.Checkout)
+	r.GET("/orders", middleware.AuthorizeRole("User"), orderController.GetOrders)
+	r.GET("/orders/:id", middleware.AuthorizeRole("User", "Admin"), orderController.GetOrderByID)
+	r.POST("/reservations", middleware.AuthorizeRole("User"), reservationController.CreateReservation)
+	r.GET("/reservations/:id", middleware.AuthorizeRole("User"), reservationController.GetReservation)
+	r.POST("/reservations/:id/confirm", middleware.AuthorizeRole("User"), reservationController.ConfirmReservation)
+	if fakePaymentSimulation {
+		r.POST("/payments/fake/:intent_id/complete", middleware.AuthorizeRole("User"), paymentController.SimulateFakePayment)
+	}
+
+	// Routes untuk petugas gerbang (Staff)
+	r.POST("/checkin", middleware.AuthorizeRole("Staff", "Admin"), checkInController.CheckIn)
 
 	// Routes untuk admin
 	adminRoutes := r.Group("/admin")
@@ -78,8 +290,45 @@ func main() {
 	adminRoutes.POST("/events", eventController.CreateEvent)
 	adminRoutes.PUT("/events/:id", eventController.UpdateEvent)
 	adminRoutes.DELETE("/events/:id", eventController.DeleteEvent)
+	adminRoutes.POST("/events/:id/cancel", cancellationController.CancelEvent)
+	adminRoutes.GET("/events/:id/cancel", cancellationController.GetCancellationProgress)
+	adminRoutes.GET("/events/:id/ticket-types", ticketTypeController.GetTicketTypes)
+	adminRoutes.POST("/events/:id/ticket-types", ticketTypeController.CreateTicketType)
+	adminRoutes.PUT("/events/:id/ticket-types/:type_id", ticketTypeController.UpdateTicketType)
+	adminRoutes.DELETE("/events/:id/ticket-types/:type_id", ticketTypeController.DeleteTicketType)
+	adminRoutes.GET("/events/:id/checkins", checkInController.GetCheckInStats)
+	adminRoutes.GET("/events/:id/pricing-rules", pricingRuleController.GetPricingRules)
+	adminRoutes.POST("/events/:id/pricing-rules", pricingRuleController.CreatePricingRule)
+	adminRoutes.PUT("/events/:id/pricing-rules/:rule_id", pricingRuleController.UpdatePricingRule)
+	adminRoutes.DELETE("/events/:id/pricing-rules/:rule_id", pricingRuleController.DeletePricingRule)
+	adminRoutes.GET("/series", seriesController.GetAllSeries)
+	adminRoutes.POST("/series", seriesController.CreateSeries)
+	adminRoutes.GET("/series/:id", seriesController.GetSeriesByID)
+	adminRoutes.PUT("/series/:id", seriesController.UpdateSeries)
+	adminRoutes.POST("/series/:id/exceptions", seriesController.AddException)
+	adminRoutes.DELETE("/series/:id/exceptions/:exception_id", seriesController.RemoveException)
+	adminRoutes.GET("/venues", venueController.GetAllVenues)
+	adminRoutes.POST("/venues", venueController.CreateVenue)
+	adminRoutes.GET("/venues/:id", venueController.GetVenueByID)
+	adminRoutes.POST("/venues/:id/seat-map", venueController.ImportSeatMap)
+	adminRoutes.GET("/promo-codes", promoController.GetAllPromoCodes)
+	adminRoutes.POST("/promo-codes", promoController.CreatePromoCode)
+	adminRoutes.GET("/promo-codes/:id", promoController.GetPromoCodeByID)
+	adminRoutes.PUT("/promo-codes/:id", promoController.UpdatePromoCode)
+	adminRoutes.GET("/promo-codes/:id/redemptions", promoController.GetRedemptions)
+	adminRoutes.PUT("/users/:id/role", userController.UpdateUserRole)
+	adminRoutes.DELETE("/users/:id", trashController.DeleteUser)
+	adminRoutes.DELETE("/tickets/:id", trashController.DeleteTicket)
+	adminRoutes.GET("/trash/events", trashController.GetDeletedEvents)
+	adminRoutes.POST("/trash/events/:id/restore", trashController.RestoreEvent)
+	adminRoutes.GET("/trash/tickets", trashController.GetDeletedTickets)
+	adminRoutes.POST("/trash/tickets/:id/restore", trashController.RestoreTicket)
+	adminRoutes.GET("/trash/users", trashController.GetDeletedUsers)
+	adminRoutes.POST("/trash/users/:id/restore", trashController.RestoreUser)
 	adminRoutes.GET("/reports/summary", reportController.GetSummaryReport)
 	adminRoutes.GET("/reports/event/:id", reportController.GetEventReport)
+	adminRoutes.GET("/reports/series/:id", reportController.GetSeriesReport)
+	adminRoutes.GET("/reports/promo-codes", reportController.GetPromoCodeReport)
 
 	adminRoutes.GET("/export/reports/summary", exportController.ExportSummaryReport)
 	adminRoutes.GET("/export/reports/event/:id", exportController.ExportEventReport)
@@ -7,7 +7,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AuthorizeRole(requiredRole string) gin.HandlerFunc {
+// AuthorizeRole mengizinkan request jika role user termasuk salah satu dari allowedRoles
+func AuthorizeRole(allowedRoles ...string) gin.HandlerFunc {
     return func(c *gin.Context) {
         role, exists := c.Get("role")
         if !exists {
@@ -17,10 +18,10 @@ func AuthorizeRole(requiredRole string) gin.HandlerFunc {
             return
         }
 
-        log.Printf("[AuthorizeRole] User role: %v, Required role: %v\n", role, requiredRole)
+        log.Printf("[AuthorizeRole] User role: %v, Allowed roles: %v\n", role, allowedRoles)
 
-        if role != requiredRole {
-            log.Printf("[AuthorizeRole] Role mismatch. User role: %v, Required: %v\n", role, requiredRole)
+        if !isRoleAllowed(role, allowedRoles) {
+            log.Printf("[AuthorizeRole] Role mismatch. User role: %v, Allowed: %v\n", role, allowedRoles)
             c.JSON(http.StatusForbidden, gin.H{"status": "error", "message": "Forbidden: insufficient permissions"})
             c.Abort()
             return
@@ -30,4 +31,11 @@ func AuthorizeRole(requiredRole string) gin.HandlerFunc {
     }
 }
 
-
+func isRoleAllowed(role interface{}, allowedRoles []string) bool {
+    for _, allowed := range allowedRoles {
+        if role == allowed {
+            return true
+        }
+    }
+    return false
+}
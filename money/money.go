@@ -0,0 +1,267 @@
+// Package money menyimpan nilai uang sebagai bilangan bulat dalam satuan terkecil mata uang
+// (mis. sen) beserta kode mata uang ISO 4217, agar penjumlahan tidak bergeser karena float.
+//
+// Aturan pembulatan: setiap hasil yang jatuh di antara dua satuan terkecil (persentase,
+// pembagian proporsional, konversi dari desimal) dibulatkan ke satuan terdekat, dan nilai
+// tepat di tengah dibulatkan menjauhi nol (0,5 sen menjadi 1 sen, -0,5 sen menjadi -1 sen).
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency dipakai jika nilai dari input atau data lama tidak menyebutkan mata uang
+var DefaultCurrency = "IDR"
+
+var (
+	ErrInvalidAmount   = errors.New("invalid money amount")
+	ErrInvalidCurrency = errors.New("currency must be a three-letter ISO 4217 code")
+)
+
+// exponents berisi mata uang ISO 4217 yang jumlah digit desimalnya bukan 2
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Money adalah nilai uang dalam satuan terkecil mata uangnya.
+// Disimpan sebagai dua kolom lewat tag gorm embedded, mis. `gorm:"embedded;embeddedPrefix:price_"`.
+type Money struct {
+	Amount   int64  `gorm:"not null;default:0" json:"amount"`                 // Satuan terkecil, mis. 150000 = IDR 1500.00
+	Currency string `gorm:"type:char(3);not null;default:''" json:"currency"` // Kode ISO 4217
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: NormalizeCurrency(currency)}
+}
+
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// NormalizeCurrency menyeragamkan kode mata uang menjadi huruf besar
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// ValidCurrency memeriksa format kode ISO 4217 (tiga huruf)
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Exponent mengembalikan jumlah digit desimal mata uang menurut ISO 4217 (default 2)
+func Exponent(currency string) int {
+	if exponent, ok := exponents[NormalizeCurrency(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// Parse membaca nilai desimal dalam satuan utama (mis. "1500.50") tanpa melewati float.
+// Digit di luar presisi mata uang dibulatkan menjauhi nol jika tepat di tengah.
+func Parse(value string, currency string) (Money, error) {
+	currency = NormalizeCurrency(currency)
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Exponent(currency))), nil))
+	amount, err := roundRat(rat.Mul(rat, scale))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// FromFloat mengubah nilai satuan utama dari float (mis. data lama atau konfigurasi) dengan aturan pembulatan yang sama seperti Parse
+func FromFloat(value float64, currency string) Money {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Zero(currency)
+	}
+	parsed, err := Parse(strconv.FormatFloat(value, 'f', -1, 64), currency)
+	if err != nil {
+		return Zero(currency)
+	}
+	return parsed
+}
+
+// Add menjumlahkan dua nilai; nilai tanpa mata uang (nilai awal penjumlahan) mengikuti mata uang lawannya.
+// Pemanggil memastikan mata uang sama lewat SameCurrency.
+func (m Money) Add(other Money) Money {
+	if m.Currency == "" {
+		m.Currency = other.Currency
+	}
+	m.Amount += other.Amount
+	return m
+}
+
+func (m Money) Sub(other Money) Money {
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+func (m Money) Mul(n int) Money {
+	m.Amount *= int64(n)
+	return m
+}
+
+// Percent menghitung percent persen dari nilai (mis. 11 untuk pajak 11%, 150 untuk kenaikan 50%)
+func (m Money) Percent(percent float64) Money {
+	rat := new(big.Rat).SetInt64(m.Amount)
+	factor, ok := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	if !ok {
+		return Zero(m.Currency)
+	}
+	rat.Mul(rat, factor)
+	rat.Quo(rat, big.NewRat(100, 1))
+	amount, err := roundRat(rat)
+	if err != nil {
+		return Zero(m.Currency)
+	}
+	return Money{Amount: amount, Currency: m.Currency}
+}
+
+// Share menghitung bagian part dari whole (mis. harga 2 dari 3 kursi)
+func (m Money) Share(part int, whole int) Money {
+	if whole == 0 {
+		return Zero(m.Currency)
+	}
+	numerator := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(int64(part)))
+	amount, err := roundRat(new(big.Rat).SetFrac(numerator, big.NewInt(int64(whole))))
+	if err != nil {
+		return Zero(m.Currency)
+	}
+	return Money{Amount: amount, Currency: m.Currency}
+}
+
+// Min mengembalikan nilai yang lebih kecil dari dua nilai bermata uang sama
+func (m Money) Min(other Money) Money {
+	if other.Amount < m.Amount {
+		return other
+	}
+	return m
+}
+
+func (m Money) IsZero() bool     { return m.Amount == 0 }
+func (m Money) IsPositive() bool { return m.Amount > 0 }
+func (m Money) IsNegative() bool { return m.Amount < 0 }
+
+// SameCurrency memeriksa kesamaan mata uang sebelum dua nilai dijumlahkan atau dibandingkan
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+// Decimal menampilkan nilai dalam satuan utama, mis. "1500.00"
+func (m Money) Decimal() string {
+	exponent := Exponent(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if exponent == 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+	scale := int64(math.Pow10(exponent))
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, exponent, amount%scale)
+}
+
+func (m Money) String() string {
+	return m.Currency + " " + m.Decimal()
+}
+
+// MarshalJSON menyertakan nilai desimal agar klien tidak perlu mengetahui jumlah digit mata uang
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+		Display  string `json:"display"`
+	}{m.Amount, m.Currency, m.Decimal()})
+}
+
+// UnmarshalJSON menerima {"amount": satuan terkecil, "currency": "IDR"}, atau angka/string desimal
+// dalam satuan utama untuk klien lama. Mata uang yang tidak disebutkan memakai DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(trimmed, "{") {
+		var value struct {
+			Amount   int64  `json:"amount"`
+			Currency string `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		if value.Currency == "" {
+			value.Currency = DefaultCurrency
+		}
+		*m = New(value.Amount, value.Currency)
+		return nil
+	}
+
+	decimal := strings.Trim(trimmed, `"`)
+	parsed, err := Parse(decimal, DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Totals menjumlahkan nilai per mata uang, mis. untuk laporan pendapatan lintas event
+type Totals map[string]Money
+
+func (t Totals) Add(m Money) {
+	t[m.Currency] = t[m.Currency].Add(m)
+}
+
+// List mengembalikan total per mata uang, diurutkan menurut kode mata uang
+func (t Totals) List() []Money {
+	list := make([]Money, 0, len(t))
+	for _, m := range t {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Currency < list[j].Currency })
+	return list
+}
+
+// roundRat membulatkan ke bilangan bulat terdekat; tepat di tengah dibulatkan menjauhi nol
+func roundRat(rat *big.Rat) (int64, error) {
+	num := new(big.Int).Set(rat.Num())
+	den := rat.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if negative {
+		quotient.Neg(quotient)
+	}
+	if !quotient.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return quotient.Int64(), nil
+}
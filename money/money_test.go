@@ -0,0 +1,195 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     int64
+	}{
+		{"1500.50", "IDR", 150050},
+		{"0.005", "USD", 1},   // Tepat di tengah dibulatkan menjauhi nol
+		{"0.004", "USD", 0},   // Di bawah tengah dibulatkan ke bawah
+		{"-0.005", "USD", -1}, // Negatif tepat di tengah juga menjauhi nol
+		{"-0.015", "USD", -2}, // Bukan pembulatan bankir
+		{"0.025", "USD", 3},   // Bukan pembulatan bankir
+		{"-12.344", "EUR", -1234},
+		{"1500", "JPY", 1500}, // Mata uang tanpa digit desimal
+		{"1500.5", "JPY", 1501},
+		{"-1500.5", "JPY", -1501},
+		{"1.2345", "KWD", 1235}, // Mata uang tiga digit desimal
+		{" 10 ", "usd", 1000},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.value, tt.currency)
+		if err != nil {
+			t.Fatalf("Parse(%q, %q) returned error: %v", tt.value, tt.currency, err)
+		}
+		if got.Amount != tt.want || got.Currency != NormalizeCurrency(tt.currency) {
+			t.Errorf("Parse(%q, %q) = %+v, want amount %d", tt.value, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, value := range []string{"", "abc", "1.2.3", "1e400"} {
+		if _, err := Parse(value, "USD"); err == nil {
+			t.Errorf("Parse(%q) expected error", value)
+		}
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		currency string
+		want     int64
+	}{
+		// 0.1+0.2 bernilai 0.30000000000000004 dalam float; harus tetap 30 sen
+		{"float sum", 0.1 + 0.2, "USD", 30},
+		{"half cent", 2.675, "USD", 268},
+		{"negative", -19.99, "USD", -1999},
+		{"zero exponent", 999.5, "JPY", 1000},
+		{"zero", 0, "IDR", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromFloat(tt.value, tt.currency)
+			if got.Amount != tt.want {
+				t.Errorf("FromFloat(%v, %q) = %d, want %d", tt.value, tt.currency, got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		amount  int64
+		percent float64
+		want    int64
+	}{
+		{1000, 11, 110},
+		{5, 50, 3},   // 2,5 menjadi 3
+		{-5, 50, -3}, // -2,5 menjadi -3
+		{15, 10, 2},  // 1,5 menjadi 2
+		{14, 10, 1},
+		{1000, 150, 1500},
+		{1000, 0, 0},
+		{333, 33.3, 111}, // 110,889
+	}
+	for _, tt := range tests {
+		got := New(tt.amount, "USD").Percent(tt.percent)
+		if got.Amount != tt.want {
+			t.Errorf("%d.Percent(%v) = %d, want %d", tt.amount, tt.percent, got.Amount, tt.want)
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		amount int64
+		part   int
+		whole  int
+		want   int64
+	}{
+		{100, 1, 3, 33},
+		{100, 2, 3, 67},
+		{101, 1, 2, 51}, // 50,5 menjadi 51
+		{-101, 1, 2, -51},
+		{100, 3, 3, 100},
+		{100, 0, 3, 0},
+		{100, 1, 0, 0},
+		{1 << 62, 3, 4, 3 << 60}, // Tidak overflow walau amount*part melebihi int64
+	}
+	for _, tt := range tests {
+		got := New(tt.amount, "USD").Share(tt.part, tt.whole)
+		if got.Amount != tt.want {
+			t.Errorf("%d.Share(%d, %d) = %d, want %d", tt.amount, tt.part, tt.whole, got.Amount, tt.want)
+		}
+	}
+}
+
+// Bagian yang dihitung kumulatif (seperti refund per kursi) harus berjumlah tepat sebesar total
+func TestShareRemaindersSumToTotal(t *testing.T) {
+	for _, amount := range []int64{0, 1, 100, 101, 999, 1000003, -1001} {
+		for whole := 1; whole <= 7; whole++ {
+			total := New(amount, "USD")
+			sum := Zero("USD")
+			for k := 1; k <= whole; k++ {
+				sum = sum.Add(total.Share(k, whole).Sub(total.Share(k-1, whole)))
+			}
+			if sum.Amount != amount {
+				t.Errorf("shares of %d over %d sum to %d", amount, whole, sum.Amount)
+			}
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{New(150050, "IDR"), "1500.50"},
+		{New(5, "USD"), "0.05"},
+		{New(-5, "USD"), "-0.05"},
+		{New(-150050, "USD"), "-1500.50"},
+		{New(1500, "JPY"), "1500"},
+		{New(-1500, "JPY"), "-1500"},
+		{New(1235, "KWD"), "1.235"},
+		{New(0, "USD"), "0.00"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.Decimal(); got != tt.want {
+			t.Errorf("%+v.Decimal() = %q, want %q", tt.money, got, tt.want)
+		}
+	}
+	if got := New(-150050, "usd").String(); got != "USD -1500.50" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestAddTakesCurrencyFromOperand(t *testing.T) {
+	got := Money{}.Add(New(100, "EUR")).Sub(New(30, "EUR"))
+	if got.Amount != 70 || got.Currency != "EUR" {
+		t.Errorf("got %+v, want EUR 70", got)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Money
+	}{
+		{`{"amount": 1999, "currency": "usd"}`, New(1999, "USD")},
+		{`{"amount": 1999}`, New(1999, DefaultCurrency)},
+		{`19.995`, New(2000, DefaultCurrency)},
+		{`"-19.995"`, New(-2000, DefaultCurrency)},
+	}
+	for _, tt := range tests {
+		var got Money
+		if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+			t.Fatalf("Unmarshal(%s) returned error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTotals(t *testing.T) {
+	totals := Totals{}
+	totals.Add(New(100, "USD"))
+	totals.Add(New(-30, "USD"))
+	totals.Add(New(500, "JPY"))
+
+	list := totals.List()
+	if len(list) != 2 || list[0] != New(500, "JPY") || list[1] != New(70, "USD") {
+		t.Errorf("List() = %+v", list)
+	}
+}
@@ -0,0 +1,172 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"eventix/money"
+	"fmt"
+	"sync"
+)
+
+// FakeProvider adalah gateway lokal di memori agar alur pembayaran bisa diuji tanpa layanan eksternal
+type FakeProvider struct {
+	mu            sync.Mutex
+	webhookSecret []byte
+	intents       map[string]*Intent
+	captured      map[string]bool
+	refunded      map[string]money.Money
+	refundKeys    map[string]string
+}
+
+func NewFakeProvider(webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		webhookSecret: []byte(webhookSecret),
+		intents:       make(map[string]*Intent),
+		captured:      make(map[string]bool),
+		refunded:      make(map[string]money.Money),
+		refundKeys:    make(map[string]string),
+	}
+}
+
+func (p *FakeProvider) CreateIntent(amount money.Money, reference string) (Intent, error) {
+	if !amount.IsPositive() {
+		return Intent{}, errors.New("amount must be greater than zero")
+	}
+	if !money.ValidCurrency(amount.Currency) {
+		return Intent{}, money.ErrInvalidCurrency
+	}
+
+	intent := Intent{
+		ID:           "pi_fake_" + randomHex(12),
+		Amount:       amount,
+		Reference:    reference,
+		Status:       StatusRequiresPayment,
+		ClientSecret: "secret_" + randomHex(16),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.intents[intent.ID] = &intent
+	return intent, nil
+}
+
+func (p *FakeProvider) Capture(intentID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return ErrIntentNotFound
+	}
+	if intent.Status != StatusSucceeded {
+		return fmt.Errorf("payment intent %s cannot be captured in status %s", intentID, intent.Status)
+	}
+	p.captured[intentID] = true
+	return nil
+}
+
+func (p *FakeProvider) Cancel(intentID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return ErrIntentNotFound
+	}
+	if p.captured[intentID] {
+		return fmt.Errorf("payment intent %s has already been captured", intentID)
+	}
+	intent.Status = StatusCancelled
+	return nil
+}
+
+func (p *FakeProvider) Refund(intentID string, amount money.Money, idempotencyKey string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if refundID, ok := p.refundKeys[idempotencyKey]; ok {
+		return refundID, nil
+	}
+
+	intent, ok := p.intents[intentID]
+	if !ok {
+		return "", ErrIntentNotFound
+	}
+	if intent.Status != StatusSucceeded && intent.Status != StatusRefunded {
+		return "", fmt.Errorf("payment intent %s cannot be refunded in status %s", intentID, intent.Status)
+	}
+	if !amount.SameCurrency(intent.Amount) {
+		return "", errors.New("refund currency does not match payment currency")
+	}
+	refunded := p.refunded[intentID].Add(amount)
+	if !amount.IsPositive() || refunded.Amount > intent.Amount.Amount {
+		return "", errors.New("refund amount exceeds captured amount")
+	}
+
+	p.refunded[intentID] = refunded
+	if refunded.Amount == intent.Amount.Amount {
+		intent.Status = StatusRefunded
+	}
+	refundID := "re_fake_" + randomHex(12)
+	p.refundKeys[idempotencyKey] = refundID
+	return refundID, nil
+}
+
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (WebhookEvent, error) {
+	if !hmac.Equal([]byte(p.sign(payload)), []byte(signature)) {
+		return WebhookEvent{}, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return WebhookEvent{}, err
+	}
+	return event, nil
+}
+
+// Simulate menyelesaikan intent seolah-olah pembeli membayar (atau gagal membayar)
+// dan mengembalikan payload webhook beserta signature-nya.
+func (p *FakeProvider) Simulate(intentID string, succeeded bool) ([]byte, string, error) {
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, "", ErrIntentNotFound
+	}
+	if intent.Status != StatusRequiresPayment {
+		p.mu.Unlock()
+		return nil, "", fmt.Errorf("payment intent %s is already %s", intentID, intent.Status)
+	}
+
+	event := WebhookEvent{Type: EventPaymentFailed, IntentID: intentID}
+	intent.Status = StatusFailed
+	if succeeded {
+		event.Type = EventPaymentSucceeded
+		intent.Status = StatusSucceeded
+	}
+	p.mu.Unlock()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, p.sign(payload), nil
+}
+
+func (p *FakeProvider) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.webhookSecret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,52 @@
+package payment
+
+import (
+	"errors"
+	"eventix/money"
+)
+
+// Status intent pembayaran
+const (
+	StatusRequiresPayment = "requires_payment"
+	StatusSucceeded       = "succeeded"
+	StatusFailed          = "failed"
+	StatusRefunded        = "refunded"
+	StatusCancelled       = "cancelled"
+)
+
+// Jenis event webhook yang dikirim provider
+const (
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+)
+
+var (
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Intent adalah permintaan pembayaran untuk satu pembelian
+type Intent struct {
+	ID           string      `json:"id"`
+	Amount       money.Money `json:"amount"`
+	Reference    string      `json:"reference"`
+	Status       string      `json:"status"`
+	ClientSecret string      `json:"client_secret"`
+}
+
+// WebhookEvent adalah notifikasi dari provider yang sudah diverifikasi
+type WebhookEvent struct {
+	Type     string `json:"type"`
+	IntentID string `json:"intent_id"`
+}
+
+// Provider adalah gateway pembayaran yang dipakai alur pembelian tiket
+type Provider interface {
+	CreateIntent(amount money.Money, reference string) (Intent, error)
+	Capture(intentID string) error
+	// Cancel membatalkan intent yang belum di-capture; intent yang sudah batal tidak dianggap error
+	Cancel(intentID string) error
+	// Refund dengan idempotencyKey yang sama hanya diproses sekali dan mengembalikan ID refund yang sama
+	Refund(intentID string, amount money.Money, idempotencyKey string) (string, error)
+	VerifyWebhook(payload []byte, signature string) (WebhookEvent, error)
+}
@@ -0,0 +1,94 @@
+// Package pricing menghitung harga satuan tiket dari harga dasar dan aturan harga dinamis event.
+//
+// Dari semua aturan aktif yang cakupan dan syaratnya terpenuhi, hanya satu yang dipakai:
+// prioritas tertinggi, lalu ambang terjual tertinggi (agar tangga harga naik bertahap),
+// lalu aturan yang dibuat paling awal.
+package pricing
+
+import (
+	"eventix/entity"
+	"eventix/money"
+	"time"
+)
+
+// State adalah keadaan penjualan saat harga dihitung
+type State struct {
+	Now          time.Time
+	StartDate    time.Time // Waktu mulai event
+	TicketTypeID *uint     // Kategori yang dibeli; kosong untuk harga event
+	SoldCount    int       // Kursi terjual pada kategori tersebut, atau pada event jika tanpa kategori
+}
+
+// Result adalah harga satuan akhir beserta aturan yang menghasilkannya
+type Result struct {
+	BasePrice money.Money
+	UnitPrice money.Money
+	Rule      *entity.PricingRule
+}
+
+// Evaluate memilih aturan yang berlaku lalu menerapkannya pada harga dasar
+func Evaluate(basePrice money.Money, rules []entity.PricingRule, state State) Result {
+	result := Result{BasePrice: basePrice, UnitPrice: basePrice}
+
+	for i := range rules {
+		rule := rules[i]
+		if !Matches(rule, state) {
+			continue
+		}
+		if result.Rule == nil || wins(rule, *result.Rule) {
+			result.Rule = &rules[i]
+		}
+	}
+
+	if result.Rule != nil {
+		result.UnitPrice = Apply(*result.Rule, basePrice)
+	}
+	return result
+}
+
+// Matches memeriksa apakah aturan aktif, mencakup kategori yang dibeli dan syaratnya terpenuhi
+func Matches(rule entity.PricingRule, state State) bool {
+	if !rule.IsActive {
+		return false
+	}
+	if rule.TicketTypeID != nil && (state.TicketTypeID == nil || *rule.TicketTypeID != *state.TicketTypeID) {
+		return false
+	}
+
+	switch rule.RuleType {
+	case "early_bird":
+		return rule.ValidUntil != nil && state.Now.Before(*rule.ValidUntil)
+	case "sold_threshold":
+		return state.SoldCount >= rule.SoldThreshold
+	case "last_minute":
+		return !state.Now.Before(state.StartDate.Add(-time.Duration(rule.HoursBeforeStart) * time.Hour))
+	}
+	return false
+}
+
+// Apply menghitung harga satuan baru dalam mata uang harga dasar; harga tidak pernah kurang dari nol
+func Apply(rule entity.PricingRule, basePrice money.Money) money.Money {
+	price := basePrice
+	switch rule.AdjustmentType {
+	case "percentage":
+		price = basePrice.Percent(100 + rule.Value)
+	case "amount":
+		price = basePrice.Add(rule.Amount)
+	case "price":
+		price = money.New(rule.Amount.Amount, basePrice.Currency)
+	}
+	if price.IsNegative() {
+		price = money.Zero(basePrice.Currency)
+	}
+	return price
+}
+
+func wins(candidate entity.PricingRule, current entity.PricingRule) bool {
+	if candidate.Priority != current.Priority {
+		return candidate.Priority > current.Priority
+	}
+	if candidate.SoldThreshold != current.SoldThreshold {
+		return candidate.SoldThreshold > current.SoldThreshold
+	}
+	return candidate.ID < current.ID
+}
@@ -0,0 +1,203 @@
+// Package receipt merender bukti pembayaran order dan e-ticket menjadi PDF
+// tanpa layanan atau dependensi eksternal.
+package receipt
+
+import (
+	"eventix/entity"
+	"eventix/money"
+	"eventix/qrcode"
+	"strconv"
+	"time"
+)
+
+const (
+	marginLeft  = 50.0
+	marginRight = pageWidth - 50.0
+	pageBottom  = pageHeight - 70.0 // Batas bawah isi sebelum pindah halaman
+	dateLayout  = "02 Jan 2006 15:04"
+)
+
+// ETicket adalah data satu kursi untuk halaman e-ticket
+type ETicket struct {
+	EventName  string
+	StartDate  time.Time
+	Venue      string
+	TicketType string
+	HolderName string
+	Code       string // Kode kursi yang dicetak di bawah QR
+	Payload    string // Payload bertanda tangan yang dipindai petugas di gerbang
+}
+
+// Render membuat PDF berisi bukti pembayaran dari invoice, diikuti satu halaman e-ticket per kursi
+func Render(invoice entity.Invoice, tickets []ETicket) ([]byte, error) {
+	doc := &document{}
+	renderInvoice(doc, invoice)
+	return renderETickets(doc, invoice, tickets)
+}
+
+// RenderETickets membuat PDF berisi halaman e-ticket saja, tanpa rincian harga dan pajak invoice,
+// untuk pemegang tiket yang bukan pembeli order (mis. penerima transfer)
+func RenderETickets(invoice entity.Invoice, tickets []ETicket) ([]byte, error) {
+	return renderETickets(&document{}, invoice, tickets)
+}
+
+func renderETickets(doc *document, invoice entity.Invoice, tickets []ETicket) ([]byte, error) {
+	for _, ticket := range tickets {
+		if err := renderETicket(doc, invoice, ticket); err != nil {
+			return nil, err
+		}
+	}
+	return doc.bytes()
+}
+
+func renderInvoice(doc *document, invoice entity.Invoice) {
+	p := doc.addPage()
+	p.text(marginLeft, 80, 24, bold, "RECEIPT")
+	p.textRight(marginRight, 80, 12, bold, "PAID")
+
+	y := 115.0
+	for _, row := range [][2]string{
+		{"Invoice number", invoice.Number},
+		{"Issued at", invoice.IssuedAt.Format(dateLayout)},
+		{"Order", invoice.OrderCode},
+	} {
+		p.text(marginLeft, y, 10, regular, row[0])
+		p.text(marginLeft+100, y, 10, bold, row[1])
+		y += 15
+	}
+
+	y += 15
+	p.text(marginLeft, y, 10, bold, "Billed to")
+	y += 15
+	p.text(marginLeft, y, 10, regular, invoice.BuyerName)
+	if invoice.BuyerEmail != "" {
+		y += 15
+		p.text(marginLeft, y, 10, regular, invoice.BuyerEmail)
+	}
+
+	// Tabel baris invoice; nominal ditulis tanpa kode mata uang yang sudah disebut di judul kolom
+	y += 35
+	currency := invoice.Total.Currency
+	header := func(p *page, y float64) {
+		p.fillRect(marginLeft, y-13, marginRight-marginLeft, 19, 0.92)
+		p.text(marginLeft+5, y, 9, bold, "Description")
+		p.textRight(340, y, 9, bold, "Qty")
+		p.textRight(425, y, 9, bold, "Unit price ("+currency+")")
+		p.textRight(485, y, 9, bold, "Discount")
+		p.textRight(marginRight-5, y, 9, bold, "Amount")
+	}
+	header(p, y)
+	y += 22
+
+	for _, line := range invoice.Lines {
+		if y > pageBottom {
+			p = doc.addPage()
+			y = 80
+			header(p, y)
+			y += 22
+		}
+		p.text(marginLeft+5, y, 9, regular, truncate(regular, 9, line.Description, 240))
+		p.textRight(340, y, 9, regular, strconv.Itoa(line.Quantity))
+		p.textRight(425, y, 9, regular, line.UnitPrice.Decimal())
+		p.textRight(485, y, 9, regular, discountText(line.Discount))
+		p.textRight(marginRight-5, y, 9, regular, line.Total.Decimal())
+		y += 8
+		p.line(marginLeft, y, marginRight, y, 0.3)
+		y += 14
+	}
+
+	// Rincian total dan pajak
+	rows := [][2]string{{"Subtotal", invoice.Subtotal.String()}}
+	if !invoice.Discount.IsZero() {
+		label := "Discount"
+		if invoice.PromoCode != "" {
+			label += " (" + invoice.PromoCode + ")"
+		}
+		rows = append(rows, [2]string{label, "-" + invoice.Discount.String()})
+	}
+	rows = append(rows,
+		[2]string{"Service fee", invoice.ServiceFee.String()},
+		[2]string{"Taxable amount", invoice.TaxableAmount.String()},
+		[2]string{"Tax (" + strconv.FormatFloat(invoice.TaxPercent, 'f', -1, 64) + "%)", invoice.Tax.String()},
+	)
+	if y+float64(len(rows)+2)*16 > pageBottom {
+		p = doc.addPage()
+		y = 80
+	}
+	y += 10
+	for _, row := range rows {
+		p.text(340, y, 10, regular, row[0])
+		p.textRight(marginRight-5, y, 10, regular, row[1])
+		y += 16
+	}
+	p.line(340, y-8, marginRight, y-8, 0.8)
+	y += 6
+	p.text(340, y, 12, bold, "Total")
+	p.textRight(marginRight-5, y, 12, bold, invoice.Total.String())
+
+	p.text(marginLeft, pageHeight-50, 9, regular, "Thank you for your purchase. E-tickets for this booking are on the following pages.")
+}
+
+func discountText(discount money.Money) string {
+	if discount.IsZero() {
+		return "-"
+	}
+	return "-" + discount.Decimal()
+}
+
+func renderETicket(doc *document, invoice entity.Invoice, ticket ETicket) error {
+	code, err := qrcode.Encode([]byte(ticket.Payload))
+	if err != nil {
+		return err
+	}
+
+	p := doc.addPage()
+	p.fillRect(0, 0, pageWidth, 110, 0.92)
+	p.text(marginLeft, 55, 12, bold, "E-TICKET")
+	p.text(marginLeft, 85, 20, bold, truncate(bold, 20, ticket.EventName, marginRight-marginLeft))
+
+	y := 150.0
+	rows := [][2]string{
+		{"Date", ticket.StartDate.Format(dateLayout)},
+		{"Venue", ticket.Venue},
+		{"Ticket type", ticket.TicketType},
+		{"Holder", ticket.HolderName},
+		{"Order", invoice.OrderCode + " / " + invoice.Number},
+	}
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		p.text(marginLeft, y, 11, regular, row[0])
+		p.text(marginLeft+90, y, 11, bold, truncate(bold, 11, row[1], marginRight-marginLeft-90))
+		y += 18
+	}
+
+	// QR digambar sebagai kotak vektor agar tetap tajam saat dicetak; quiet zone 4 modul
+	const qrSize = 240.0
+	module := qrSize / float64(code.Size+8)
+	left := (pageWidth - qrSize) / 2
+	top := y + 30
+	for row := 0; row < code.Size; row++ {
+		for col := 0; col < code.Size; {
+			if !code.Modules[row][col] {
+				col++
+				continue
+			}
+			// Modul gelap yang bersebelahan digabung menjadi satu kotak
+			run := col
+			for run < code.Size && code.Modules[row][run] {
+				run++
+			}
+			p.fillRect(left+float64(col+4)*module, top+float64(row+4)*module, float64(run-col)*module, module, 0)
+			col = run
+		}
+	}
+
+	y = top + qrSize + 20
+	p.text((pageWidth-textWidth(bold, 14, ticket.Code))/2, y, 14, bold, ticket.Code)
+	y += 25
+	note := "Present this QR code at the entrance. Each code admits one person once."
+	p.text((pageWidth-textWidth(regular, 10, note))/2, y, 10, regular, note)
+	return nil
+}
@@ -0,0 +1,238 @@
+// Package recurrence mengurai dan menjabarkan aturan pengulangan bergaya RFC 5545 (RRULE).
+//
+// Subset yang didukung: FREQ=DAILY|WEEKLY|MONTHLY|YEARLY, INTERVAL, COUNT, UNTIL,
+// BYDAY (hanya untuk WEEKLY, tanpa awalan angka) dan BYMONTHDAY (hanya untuk MONTHLY, 1..31).
+// Minggu dimulai hari Senin (WKST=MO).
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxIterations membatasi penjabaran agar aturan yang tidak pernah cocok tidak berputar tanpa akhir
+const maxIterations = 100000
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule adalah hasil parse RRULE
+type Rule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []time.Weekday
+	ByMonthDay int
+}
+
+// Parse mengurai RRULE seperti "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+// Awalan "RRULE:" boleh ada. UNTIL tanpa zona waktu dibaca dalam zona loc.
+func Parse(s string, loc *time.Location) (Rule, error) {
+	rule := Rule{Interval: 1}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, ErrInvalidRule
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return Rule{}, fmt.Errorf("%w: %s is given more than once", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			switch value {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				rule.Freq = value
+			default:
+				return Rule{}, fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRule, value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive number", ErrInvalidRule)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: COUNT must be a positive number", ErrInvalidRule)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value, loc)
+			if err != nil {
+				return Rule{}, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return Rule{}, fmt.Errorf("%w: unsupported BYDAY value %s", ErrInvalidRule, day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 31 {
+				return Rule{}, fmt.Errorf("%w: BYMONTHDAY must be between 1 and 31", ErrInvalidRule)
+			}
+			rule.ByMonthDay = n
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRule)
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != "WEEKLY" {
+		return Rule{}, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRule)
+	}
+	if rule.ByMonthDay > 0 && rule.Freq != "MONTHLY" {
+		return Rule{}, fmt.Errorf("%w: BYMONTHDAY is only supported with FREQ=MONTHLY", ErrInvalidRule)
+	}
+	return rule, nil
+}
+
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		// UNTIL berupa tanggal mencakup seluruh hari tersebut
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ", ErrInvalidRule)
+}
+
+// Bounded menandakan aturan memiliki akhir (COUNT atau UNTIL)
+func (r Rule) Bounded() bool {
+	return r.Count > 0 || r.Until != nil
+}
+
+// Expand mengembalikan waktu mulai setiap kemunculan sejak dtstart sampai paling lambat to (inklusif),
+// dengan tetap menghormati COUNT dan UNTIL. Jam, menit dan zona waktu diambil dari dtstart.
+func (r Rule) Expand(dtstart time.Time, to time.Time) []time.Time {
+	if r.Until != nil && r.Until.Before(to) {
+		to = *r.Until
+	}
+
+	var occurrences []time.Time
+	emitted := 0
+	// emit mengembalikan false jika penjabaran harus berhenti
+	emit := func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if t.Before(dtstart) {
+			return true
+		}
+		occurrences = append(occurrences, t)
+		emitted++
+		return r.Count == 0 || emitted < r.Count
+	}
+
+	for period := 0; period < maxIterations; period++ {
+		step := period * r.Interval
+		switch r.Freq {
+		case "DAILY":
+			if !emit(dtstart.AddDate(0, 0, step)) {
+				return occurrences
+			}
+		case "WEEKLY":
+			weekStart := dtstart.AddDate(0, 0, -mondayOffset(dtstart.Weekday())+7*step)
+			if weekStart.After(to) {
+				return occurrences
+			}
+			for _, offset := range r.weekOffsets(dtstart) {
+				if !emit(weekStart.AddDate(0, 0, offset)) {
+					return occurrences
+				}
+			}
+		case "MONTHLY":
+			monthStart := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1,
+				dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+			if monthStart.After(to) {
+				return occurrences
+			}
+			day := dtstart.Day()
+			if r.ByMonthDay > 0 {
+				day = r.ByMonthDay
+			}
+			// Bulan yang tidak memiliki tanggal tersebut dilewati, sesuai RFC 5545
+			if t := monthStart.AddDate(0, 0, day-1); t.Month() == monthStart.Month() {
+				if !emit(t) {
+					return occurrences
+				}
+			}
+		case "YEARLY":
+			t := time.Date(dtstart.Year()+step, dtstart.Month(), dtstart.Day(),
+				dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+			if t.After(to) {
+				return occurrences
+			}
+			// 29 Februari hanya muncul pada tahun kabisat
+			if t.Month() == dtstart.Month() {
+				if !emit(t) {
+					return occurrences
+				}
+			}
+		default:
+			return occurrences
+		}
+	}
+	return occurrences
+}
+
+// weekOffsets mengembalikan selisih hari dari Senin untuk setiap BYDAY, terurut;
+// tanpa BYDAY dipakai hari dari dtstart
+func (r Rule) weekOffsets(dtstart time.Time) []int {
+	if len(r.ByDay) == 0 {
+		return []int{mondayOffset(dtstart.Weekday())}
+	}
+	seen := map[int]bool{}
+	var offsets []int
+	for _, day := range r.ByDay {
+		offset := mondayOffset(day)
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Ints(offsets)
+	return offsets
+}
+
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
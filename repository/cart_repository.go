@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CartRepository interface {
+	GetCartItems(userID uint) ([]entity.CartItem, error)
+	GetCartItemsForUpdate(userID uint) ([]entity.CartItem, error)
+	FindCartItem(userID uint, eventID uint, ticketTypeID *uint) (entity.CartItem, error)
+	CreateCartItem(item entity.CartItem) (entity.CartItem, error)
+	UpdateCartItemQuantity(id uint, quantity int) error
+	DeleteCartItem(userID uint, id uint) error
+	ClearCart(userID uint) error
+}
+
+type cartRepository struct {
+	db *gorm.DB
+}
+
+func NewCartRepository(db *gorm.DB) CartRepository {
+	return &cartRepository{db: db}
+}
+
+func (r *cartRepository) GetCartItems(userID uint) ([]entity.CartItem, error) {
+	var items []entity.CartItem
+	result := r.db.Where("user_id = ?", userID).Order("event_id ASC, ticket_type_id ASC, id ASC").Find(&items)
+	return items, result.Error
+}
+
+// GetCartItemsForUpdate mengunci keranjang agar checkout yang sama tidak diproses dua kali.
+// Urutan berdasarkan event menjaga urutan lock event tetap sama antar checkout.
+func (r *cartRepository) GetCartItemsForUpdate(userID uint) ([]entity.CartItem, error) {
+	var items []entity.CartItem
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		Order("event_id ASC, ticket_type_id ASC, id ASC").
+		Find(&items)
+	return items, result.Error
+}
+
+func (r *cartRepository) FindCartItem(userID uint, eventID uint, ticketTypeID *uint) (entity.CartItem, error) {
+	var item entity.CartItem
+	query := r.db.Where("user_id = ? AND event_id = ?", userID, eventID)
+	if ticketTypeID == nil {
+		query = query.Where("ticket_type_id IS NULL")
+	} else {
+		query = query.Where("ticket_type_id = ?", *ticketTypeID)
+	}
+	result := query.First(&item)
+	return item, result.Error
+}
+
+func (r *cartRepository) CreateCartItem(item entity.CartItem) (entity.CartItem, error) {
+	result := r.db.Create(&item)
+	return item, result.Error
+}
+
+func (r *cartRepository) UpdateCartItemQuantity(id uint, quantity int) error {
+	return r.db.Model(&entity.CartItem{}).Where("id = ?", id).Update("quantity", quantity).Error
+}
+
+func (r *cartRepository) DeleteCartItem(userID uint, id uint) error {
+	result := r.db.Where("user_id = ?", userID).Delete(&entity.CartItem{}, id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
+
+func (r *cartRepository) ClearCart(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&entity.CartItem{}).Error
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"eventix/entity"
+	"eventix/money"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventCancellationRepository interface {
+	CreateCancellation(cancellation entity.EventCancellation) (entity.EventCancellation, error)
+	GetCancellationByIDForUpdate(id uint) (entity.EventCancellation, error)
+	GetLatestCancellationByEventID(eventID uint) (entity.EventCancellation, error)
+	GetUnfinishedCancellationIDs() ([]uint, error)
+	RecordTicketProcessed(id uint, ticketID uint, refunded money.Money) error
+	RecordTicketFailed(id uint, ticketID uint, message string) error
+	AdvanceCursor(id uint, ticketID uint) error
+	MarkCancellationCompleted(id uint, completedAt time.Time) error
+	MarkCancellationCompletedWithErrors(id uint, failed int64, message string) error
+}
+
+type eventCancellationRepository struct {
+	db *gorm.DB
+}
+
+func NewEventCancellationRepository(db *gorm.DB) EventCancellationRepository {
+	return &eventCancellationRepository{db: db}
+}
+
+func (r *eventCancellationRepository) CreateCancellation(cancellation entity.EventCancellation) (entity.EventCancellation, error) {
+	result := r.db.Create(&cancellation)
+	return cancellation, result.Error
+}
+
+// GetCancellationByIDForUpdate mengunci job agar hanya satu worker yang memproses batch pada satu waktu
+func (r *eventCancellationRepository) GetCancellationByIDForUpdate(id uint) (entity.EventCancellation, error) {
+	var cancellation entity.EventCancellation
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&cancellation, id)
+	return cancellation, result.Error
+}
+
+func (r *eventCancellationRepository) GetLatestCancellationByEventID(eventID uint) (entity.EventCancellation, error) {
+	var cancellation entity.EventCancellation
+	result := r.db.Where("event_id = ?", eventID).Order("id DESC").First(&cancellation)
+	return cancellation, result.Error
+}
+
+// GetUnfinishedCancellationIDs mengambil job yang masih berjalan atau masih memiliki tiket gagal untuk dicoba ulang
+func (r *eventCancellationRepository) GetUnfinishedCancellationIDs() ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.EventCancellation{}).
+		Where("status IN ?", []string{"running", "completed_with_errors"}).
+		Order("id ASC").
+		Pluck("id", &ids)
+	return ids, result.Error
+}
+
+func (r *eventCancellationRepository) RecordTicketProcessed(id uint, ticketID uint, refunded money.Money) error {
+	result := r.db.Model(&entity.EventCancellation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"processed_tickets":       gorm.Expr("processed_tickets + ?", 1),
+		"refunded_total_amount":   gorm.Expr("refunded_total_amount + ?", refunded.Amount),
+		"refunded_total_currency": refunded.Currency,
+		"last_ticket_id":          gorm.Expr("GREATEST(last_ticket_id, ?)", ticketID),
+	})
+	return result.Error
+}
+
+// RecordTicketFailed mencatat tiket yang gagal pada lintasan pertama; tiket tetap terbuka dan dicoba ulang setelah kursor habis
+func (r *eventCancellationRepository) RecordTicketFailed(id uint, ticketID uint, message string) error {
+	result := r.db.Model(&entity.EventCancellation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_tickets": gorm.Expr("failed_tickets + ?", 1),
+		"last_ticket_id": gorm.Expr("GREATEST(last_ticket_id, ?)", ticketID),
+		"last_error":     message,
+	})
+	return result.Error
+}
+
+// AdvanceCursor melewati tiket yang sudah tidak perlu dibatalkan (mis. dibatalkan user lebih dulu)
+func (r *eventCancellationRepository) AdvanceCursor(id uint, ticketID uint) error {
+	result := r.db.Model(&entity.EventCancellation{}).Where("id = ?", id).
+		Update("last_ticket_id", gorm.Expr("GREATEST(last_ticket_id, ?)", ticketID))
+	return result.Error
+}
+
+func (r *eventCancellationRepository) MarkCancellationCompleted(id uint, completedAt time.Time) error {
+	result := r.db.Model(&entity.EventCancellation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":         "completed",
+		"failed_tickets": 0,
+		"last_error":     "",
+		"completed_at":   completedAt,
+	})
+	return result.Error
+}
+
+// MarkCancellationCompletedWithErrors menandai kursor sudah habis tetapi masih ada tiket yang gagal dibatalkan
+func (r *eventCancellationRepository) MarkCancellationCompletedWithErrors(id uint, failed int64, message string) error {
+	result := r.db.Model(&entity.EventCancellation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":         "completed_with_errors",
+		"failed_tickets": failed,
+		"last_error":     message,
+	})
+	return result.Error
+}
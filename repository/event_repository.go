@@ -2,17 +2,28 @@ package repository
 
 import (
     "eventix/entity"
+    "time"
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type EventRepository interface {
     GetAllEvents(page int, size int, name string, status string) ([]entity.Event, int64, error)
     GetEventByID(id uint) (entity.Event, error)
+    GetEventByIDForUpdate(id uint) (entity.Event, error)
     CreateEvent(event entity.Event) (entity.Event, error)
     UpdateEvent(event entity.Event) (entity.Event, error)
+    AddSoldCount(id uint, delta int) error
+    AddReservedCount(id uint, delta int) error
+    StartDueEvents(now time.Time) (int64, error)
+    CompleteDueEvents(now time.Time) (int64, error)
     DeleteEvent(id uint) error
+    GetDeletedEvents(page int, size int) ([]entity.Event, int64, error)
+    GetDeletedEventByID(id uint) (entity.Event, error)
+    RestoreEvent(id uint) error
+    PurgeDeletedEvents(before time.Time) (int64, error)
     SearchEvents(name string, startDate string, capacity int) ([]entity.Event, error)
-    IsEventNameUnique(name string, excludeID uint) (bool, error)
+    IsEventNameUnique(name string, excludeID uint, seriesID *uint) (bool, error)
     SearchAndFilterEvents(filters map[string]interface{}, page int, size int) ([]entity.Event, int64, error)
 }
 
@@ -37,9 +48,11 @@ func (r *eventRepository) GetAllEvents(page int, size int, name string, status s
 		query = query.Where("name LIKE ?", "%"+name+"%")
 	}
 
-	// Filter berdasarkan status event
+	// Filter berdasarkan status event; tanpa filter, event draft tidak ikut ditampilkan
 	if status != "" {
 		query = query.Where("status = ?", status)
+	} else {
+		query = query.Where("status <> ?", "draft")
 	}
 
 	// Hitung total data sebelum pagination
@@ -58,6 +71,13 @@ func (r *eventRepository) GetEventByID(id uint) (entity.Event, error) {
     return event, result.Error
 }
 
+// GetEventByIDForUpdate mengunci baris event (SELECT ... FOR UPDATE) sampai transaksi selesai
+func (r *eventRepository) GetEventByIDForUpdate(id uint) (entity.Event, error) {
+    var event entity.Event
+    result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, id)
+    return event, result.Error
+}
+
 func (r *eventRepository) CreateEvent(event entity.Event) (entity.Event, error) {
     result := r.db.Create(&event)
     return event, result.Error
@@ -72,8 +92,8 @@ func (r *eventRepository) UpdateEvent(event entity.Event) (entity.Event, error)
         return entity.Event{}, err
     }
 
-    // Update hanya pada record yang ditemukan
-    if err := r.db.Model(&existing).Updates(event).Error; err != nil {
+    // Update hanya pada record yang ditemukan; penghitung kursi hanya diubah lewat AddSoldCount
+    if err := r.db.Model(&existing).Omit("sold_count", "reserved_count").Updates(event).Error; err != nil {
         return entity.Event{}, err
     }
 
@@ -81,11 +101,90 @@ func (r *eventRepository) UpdateEvent(event entity.Event) (entity.Event, error)
     return existing, nil
 }
 
+// AddSoldCount menambah (delta positif) atau mengurangi (delta negatif) jumlah kursi terjual
+func (r *eventRepository) AddSoldCount(id uint, delta int) error {
+    result := r.db.Model(&entity.Event{}).Where("id = ?", id).Update("sold_count", gorm.Expr("sold_count + ?", delta))
+    return result.Error
+}
+
+// AddReservedCount menambah atau mengurangi jumlah kursi yang sedang ditahan reservasi
+func (r *eventRepository) AddReservedCount(id uint, delta int) error {
+    result := r.db.Model(&entity.Event{}).Where("id = ?", id).Update("reserved_count", gorm.Expr("reserved_count + ?", delta))
+    return result.Error
+}
+
+// StartDueEvents mengubah event active yang sudah dimulai menjadi ongoing
+func (r *eventRepository) StartDueEvents(now time.Time) (int64, error) {
+    result := r.db.Model(&entity.Event{}).
+        Where("status = ? AND start_date <= ? AND end_date > ?", "active", now, now).
+        Update("status", "ongoing")
+    return result.RowsAffected, result.Error
+}
+
+// CompleteDueEvents mengubah event active atau ongoing yang sudah berakhir menjadi completed
+func (r *eventRepository) CompleteDueEvents(now time.Time) (int64, error) {
+    result := r.db.Model(&entity.Event{}).
+        Where("status IN ? AND end_date <= ?", []string{"active", "ongoing"}, now).
+        Update("status", "completed")
+    return result.RowsAffected, result.Error
+}
+
+// DeleteEvent melakukan soft delete; event masih bisa dipulihkan sampai dibersihkan job purge
 func (r *eventRepository) DeleteEvent(id uint) error {
     result := r.db.Delete(&entity.Event{}, id)
     return result.Error
 }
 
+func (r *eventRepository) GetDeletedEvents(page int, size int) ([]entity.Event, int64, error) {
+    var events []entity.Event
+    var totalItems int64
+
+    query := r.db.Unscoped().Model(&entity.Event{}).Where("deleted_at IS NOT NULL")
+    if err := query.Count(&totalItems).Error; err != nil {
+        return nil, 0, err
+    }
+
+    offset := (page - 1) * size
+    result := query.Order("deleted_at DESC").Offset(offset).Limit(size).Find(&events)
+    return events, totalItems, result.Error
+}
+
+func (r *eventRepository) GetDeletedEventByID(id uint) (entity.Event, error) {
+    var event entity.Event
+    result := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&event)
+    return event, result.Error
+}
+
+func (r *eventRepository) RestoreEvent(id uint) error {
+    result := r.db.Unscoped().Model(&entity.Event{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+    if result.Error == nil && result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return result.Error
+}
+
+// PurgeDeletedEvents menghapus permanen event terhapus yang melewati batas retensi;
+// event yang masih dirujuk tiket (termasuk tiket terhapus) dilewati sampai tiketnya dibersihkan
+func (r *eventRepository) PurgeDeletedEvents(before time.Time) (int64, error) {
+    var ids []uint
+    err := r.db.Unscoped().Model(&entity.Event{}).
+        Where("deleted_at < ?", before).
+        Where("NOT EXISTS (SELECT 1 FROM tickets WHERE tickets.event_id = events.id)").
+        Pluck("id", &ids).Error
+    if err != nil {
+        return 0, err
+    }
+    if len(ids) == 0 {
+        return 0, nil
+    }
+
+    if err := r.db.Where("event_id IN ?", ids).Delete(&entity.TicketType{}).Error; err != nil {
+        return 0, err
+    }
+    result := r.db.Unscoped().Where("id IN ?", ids).Delete(&entity.Event{})
+    return result.RowsAffected, result.Error
+}
+
 func (r *eventRepository) SearchEvents(name string, startDate string, capacity int) ([]entity.Event, error) {
     var events []entity.Event
     query := r.db
@@ -104,13 +203,26 @@ func (r *eventRepository) SearchEvents(name string, startDate string, capacity i
     return events, result.Error
 }
 
-func (r *eventRepository) IsEventNameUnique(name string, excludeID uint) (bool, error) {
+// IsEventNameUnique mengecek nama event; kemunculan dalam seri yang sama boleh memakai nama seri
+func (r *eventRepository) IsEventNameUnique(name string, excludeID uint, seriesID *uint) (bool, error) {
     var count int64
     query := r.db.Model(&entity.Event{}).Where("name = ?", name)
     if excludeID > 0 {
         query = query.Where("id != ?", excludeID)
     }
-    err := query.Count(&count).Error
+    if seriesID != nil {
+        query = query.Where("series_id IS NULL OR series_id != ?", *seriesID)
+    }
+    if err := query.Count(&count).Error; err != nil || count > 0 {
+        return false, err
+    }
+
+    // Nama seri juga dicadangkan walaupun kemunculannya belum dibuat
+    seriesQuery := r.db.Model(&entity.EventSeries{}).Where("name = ?", name)
+    if seriesID != nil {
+        seriesQuery = seriesQuery.Where("id != ?", *seriesID)
+    }
+    err := seriesQuery.Count(&count).Error
     return count == 0, err
 }
 
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"eventix/config"
+	"eventix/entity"
+	"eventix/money"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB menghubungkan ke database MySQL khusus pengujian dari TEST_DB_DSN.
+// Pengujian query dilewati jika variabel itu tidak diisi.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set; skipping MySQL integration test")
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	if err := config.Migrate(db); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+// Jadwal event uji berada jauh di masa lalu agar query transisi tidak menyentuh data pengujian lain
+func TestEventStatusTransitions(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewEventRepository(db)
+
+	start := time.Date(2001, 11, 1, 19, 0, 0, 0, time.UTC)
+	newEvent := func(status string, length time.Duration) entity.Event {
+		event := entity.Event{
+			Name:      fmt.Sprintf("Lifecycle test %s %d", status, time.Now().UnixNano()),
+			StartDate: start,
+			EndDate:   start.Add(length),
+			Capacity:  10,
+			Price:     money.New(10000, "IDR"),
+			Status:    status,
+		}
+		if err := db.Create(&event).Error; err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		t.Cleanup(func() { db.Unscoped().Delete(&entity.Event{}, event.ID) })
+		return event
+	}
+	active := newEvent("active", 3*time.Hour)
+	draft := newEvent("draft", 3*time.Hour)
+	cancelled := newEvent("cancelled", 3*time.Hour)
+	// Event singkat yang sudah berakhir sebelum job berjalan lagi
+	short := newEvent("active", 30*time.Minute)
+
+	steps := []struct {
+		name      string
+		now       time.Time
+		started   int64
+		completed int64
+		want      map[uint]string
+	}{
+		{"before start", start.Add(-time.Hour), 0, 0, map[uint]string{active.ID: "active", draft.ID: "draft", cancelled.ID: "cancelled", short.ID: "active"}},
+		{"at start", start, 2, 0, map[uint]string{active.ID: "ongoing", draft.ID: "draft", cancelled.ID: "cancelled", short.ID: "ongoing"}},
+		{"short event ends", start.Add(30 * time.Minute), 0, 1, map[uint]string{active.ID: "ongoing", short.ID: "completed"}},
+		{"at end", start.Add(3 * time.Hour), 0, 1, map[uint]string{active.ID: "completed", draft.ID: "draft", cancelled.ID: "cancelled"}},
+		{"after end", start.Add(27 * time.Hour), 0, 0, map[uint]string{active.ID: "completed", short.ID: "completed"}},
+	}
+	for _, step := range steps {
+		completed, err := repo.CompleteDueEvents(step.now)
+		if err != nil {
+			t.Fatalf("%s: complete: %v", step.name, err)
+		}
+		started, err := repo.StartDueEvents(step.now)
+		if err != nil {
+			t.Fatalf("%s: start: %v", step.name, err)
+		}
+		if started != step.started || completed != step.completed {
+			t.Errorf("%s: started %d and completed %d events, want %d and %d", step.name, started, completed, step.started, step.completed)
+		}
+		for id, status := range step.want {
+			var event entity.Event
+			if err := db.First(&event, id).Error; err != nil {
+				t.Fatalf("%s: failed to reload event %d: %v", step.name, id, err)
+			}
+			if event.Status != status {
+				t.Errorf("%s: event %d status %q, want %q", step.name, id, event.Status, status)
+			}
+		}
+	}
+}
+
+// Event yang sudah berakhir tidak pernah dimulai, walaupun job belum pernah menyelesaikannya
+func TestStartDueEventsSkipsEndedEvents(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewEventRepository(db)
+
+	start := time.Date(2001, 11, 1, 19, 0, 0, 0, time.UTC)
+	event := entity.Event{
+		Name:      fmt.Sprintf("Lifecycle test ended %d", time.Now().UnixNano()),
+		StartDate: start,
+		EndDate:   start.Add(time.Hour),
+		Capacity:  10,
+		Price:     money.New(10000, "IDR"),
+		Status:    "active",
+	}
+	if err := db.Create(&event).Error; err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	t.Cleanup(func() { db.Unscoped().Delete(&entity.Event{}, event.ID) })
+
+	started, err := repo.StartDueEvents(start.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started != 0 {
+		t.Fatalf("started %d events, want 0", started)
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+)
+
+type EventSeatRepository interface {
+	CreateEventSeats(seats []entity.EventSeat) error
+	GetTakenSeatIDs(eventID uint) ([]uint, error)
+	ReleaseSeatsByTicketID(ticketID uint) error
+	ReleaseSeatByTicketItemID(itemID uint) error
+	MoveSeatToTicket(itemID uint, ticketID uint) error
+}
+
+type eventSeatRepository struct {
+	db *gorm.DB
+}
+
+func NewEventSeatRepository(db *gorm.DB) EventSeatRepository {
+	return &eventSeatRepository{db: db}
+}
+
+// CreateEventSeats mengambil kursi sekaligus; jika salah satu sudah diambil, unique index membuat insert gagal
+func (r *eventSeatRepository) CreateEventSeats(seats []entity.EventSeat) error {
+	return r.db.Create(&seats).Error
+}
+
+func (r *eventSeatRepository) GetTakenSeatIDs(eventID uint) ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.EventSeat{}).Where("event_id = ?", eventID).Pluck("seat_id", &ids)
+	return ids, result.Error
+}
+
+// ReleaseSeatsByTicketID mengembalikan semua kursi tiket agar bisa dipilih pembeli lain
+func (r *eventSeatRepository) ReleaseSeatsByTicketID(ticketID uint) error {
+	return r.db.Where("ticket_id = ?", ticketID).Delete(&entity.EventSeat{}).Error
+}
+
+func (r *eventSeatRepository) ReleaseSeatByTicketItemID(itemID uint) error {
+	return r.db.Where("ticket_item_id = ?", itemID).Delete(&entity.EventSeat{}).Error
+}
+
+// MoveSeatToTicket mengikuti kursi yang dipindahkan ke tiket lain agar pembatalan tiket tujuan ikut melepasnya
+func (r *eventSeatRepository) MoveSeatToTicket(itemID uint, ticketID uint) error {
+	return r.db.Model(&entity.EventSeat{}).Where("ticket_item_id = ?", itemID).Update("ticket_id", ticketID).Error
+}
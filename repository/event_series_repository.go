@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventSeriesRepository interface {
+	GetAllSeries() ([]entity.EventSeries, error)
+	GetSeriesByID(id uint) (entity.EventSeries, error)
+	GetSeriesByIDForUpdate(id uint) (entity.EventSeries, error)
+	GetSeriesWithOccurrences(id uint) (entity.EventSeries, error)
+	GetSeriesIDsToMaterialize(until time.Time) ([]uint, error)
+	IsSeriesNameUnique(name string) (bool, error)
+	CreateSeries(series entity.EventSeries) (entity.EventSeries, error)
+	UpdateSeriesTemplate(series entity.EventSeries) error
+	SetMaterializedUntil(id uint, until time.Time) error
+	GetOccurrenceStarts(seriesID uint) ([]time.Time, error)
+	GetOccurrenceByStart(seriesID uint, start time.Time) (entity.Event, error)
+	GetUpcomingOccurrencesForUpdate(seriesID uint, now time.Time) ([]entity.Event, error)
+	ApplyTemplateToOccurrences(ids []uint, series entity.EventSeries) error
+	GetOccurrenceStats(seriesID uint) ([]entity.SeriesOccurrenceStats, error)
+	CreateException(exception entity.EventSeriesException) (entity.EventSeriesException, error)
+	GetExceptionByID(seriesID uint, id uint) (entity.EventSeriesException, error)
+	GetExceptionStarts(seriesID uint) ([]time.Time, error)
+	DeleteException(id uint) error
+	DeleteExceptionByStart(seriesID uint, start time.Time) error
+}
+
+type eventSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewEventSeriesRepository(db *gorm.DB) EventSeriesRepository {
+	return &eventSeriesRepository{db: db}
+}
+
+func (r *eventSeriesRepository) GetAllSeries() ([]entity.EventSeries, error) {
+	var series []entity.EventSeries
+	result := r.db.Order("id DESC").Find(&series)
+	return series, result.Error
+}
+
+func (r *eventSeriesRepository) GetSeriesByID(id uint) (entity.EventSeries, error) {
+	var series entity.EventSeries
+	result := r.db.First(&series, id)
+	return series, result.Error
+}
+
+// GetSeriesByIDForUpdate mengunci seri agar materialisasi dan perubahan template tidak berjalan bersamaan
+func (r *eventSeriesRepository) GetSeriesByIDForUpdate(id uint) (entity.EventSeries, error) {
+	var series entity.EventSeries
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&series, id)
+	return series, result.Error
+}
+
+func (r *eventSeriesRepository) GetSeriesWithOccurrences(id uint) (entity.EventSeries, error) {
+	var series entity.EventSeries
+	result := r.db.
+		Preload("Exceptions", func(db *gorm.DB) *gorm.DB { return db.Order("occurrence_start ASC") }).
+		Preload("Occurrences", func(db *gorm.DB) *gorm.DB { return db.Order("start_date ASC") }).
+		First(&series, id)
+	return series, result.Error
+}
+
+// GetSeriesIDsToMaterialize mengambil seri yang kemunculannya belum dibuat sampai waktu until
+func (r *eventSeriesRepository) GetSeriesIDsToMaterialize(until time.Time) ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.EventSeries{}).
+		Where("materialized_until IS NULL OR materialized_until < ?", until).
+		Order("id ASC").
+		Pluck("id", &ids)
+	return ids, result.Error
+}
+
+func (r *eventSeriesRepository) IsSeriesNameUnique(name string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.EventSeries{}).Where("name = ?", name).Count(&count).Error
+	return count == 0, err
+}
+
+func (r *eventSeriesRepository) CreateSeries(series entity.EventSeries) (entity.EventSeries, error) {
+	result := r.db.Create(&series)
+	return series, result.Error
+}
+
+// UpdateSeriesTemplate menyimpan nilai template; nama, aturan, jadwal dan venue seri tidak bisa diubah
+func (r *eventSeriesRepository) UpdateSeriesTemplate(series entity.EventSeries) error {
+	return r.db.Model(&entity.EventSeries{ID: series.ID}).
+		Select("Description", "Capacity", "price_amount", "price_currency", "Status", "ImageURL", "SalesOpenDaysBefore", "RefundFullDaysBefore",
+			"RefundPartialPercent", "MinPerOrder", "MaxPerOrder", "MaxPerUser", "TransferPolicy", "TransferCutoffHours").
+		Updates(series).Error
+}
+
+func (r *eventSeriesRepository) SetMaterializedUntil(id uint, until time.Time) error {
+	return r.db.Model(&entity.EventSeries{}).Where("id = ?", id).Update("materialized_until", until).Error
+}
+
+// GetOccurrenceStarts mengambil waktu kemunculan yang sudah dibuat, termasuk yang sudah di-soft delete
+func (r *eventSeriesRepository) GetOccurrenceStarts(seriesID uint) ([]time.Time, error) {
+	var starts []time.Time
+	result := r.db.Unscoped().Model(&entity.Event{}).Where("series_id = ?", seriesID).Pluck("occurrence_start", &starts)
+	return starts, result.Error
+}
+
+// GetOccurrenceByStart mengambil kemunculan berdasarkan waktu menurut aturan, termasuk yang sudah di-soft delete
+func (r *eventSeriesRepository) GetOccurrenceByStart(seriesID uint, start time.Time) (entity.Event, error) {
+	var event entity.Event
+	result := r.db.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("series_id = ? AND occurrence_start = ?", seriesID, start).
+		First(&event)
+	return event, result.Error
+}
+
+// GetUpcomingOccurrencesForUpdate mengunci kemunculan yang belum dimulai dan masih mengikuti template seri
+func (r *eventSeriesRepository) GetUpcomingOccurrencesForUpdate(seriesID uint, now time.Time) ([]entity.Event, error) {
+	var events []entity.Event
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("series_id = ? AND series_override = ? AND start_date > ? AND status IN ?", seriesID, false, now, []string{"draft", "active"}).
+		Order("id ASC").
+		Find(&events)
+	return events, result.Error
+}
+
+// ApplyTemplateToOccurrences menyalin nilai template seri ke kemunculan; nilai nol ikut disalin
+func (r *eventSeriesRepository) ApplyTemplateToOccurrences(ids []uint, series entity.EventSeries) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&entity.Event{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"description":             series.Description,
+		"capacity":                series.Capacity,
+		"price_amount":            series.Price.Amount,
+		"price_currency":          series.Price.Currency,
+		"status":                  series.Status,
+		"image_url":               series.ImageURL,
+		"refund_full_days_before": series.RefundFullDaysBefore,
+		"refund_partial_percent":  series.RefundPartialPercent,
+		"min_per_order":           series.MinPerOrder,
+		"max_per_order":           series.MaxPerOrder,
+		"max_per_user":            series.MaxPerUser,
+		"transfer_policy":         series.TransferPolicy,
+		"transfer_cutoff_hours":   series.TransferCutoffHours,
+	}).Error
+}
+
+// GetOccurrenceStats menghitung penjualan setiap kemunculan seri dari tiket yang sudah dibayar
+func (r *eventSeriesRepository) GetOccurrenceStats(seriesID uint) ([]entity.SeriesOccurrenceStats, error) {
+	var stats []entity.SeriesOccurrenceStats
+	result := r.db.Model(&entity.Event{}).
+		Select("events.id AS event_id, events.start_date, events.status, events.capacity, events.sold_count, events.reserved_count, "+
+			"COUNT(tickets.id) AS tickets_sold, COALESCE(SUM(tickets.price_amount), 0) AS revenue_amount, events.price_currency AS revenue_currency").
+		Joins("LEFT JOIN tickets ON tickets.event_id = events.id AND tickets.status = ? AND tickets.deleted_at IS NULL", "purchased").
+		Where("events.series_id = ?", seriesID).
+		Group("events.id").
+		Order("events.start_date ASC").
+		Scan(&stats)
+	return stats, result.Error
+}
+
+func (r *eventSeriesRepository) CreateException(exception entity.EventSeriesException) (entity.EventSeriesException, error) {
+	result := r.db.Create(&exception)
+	return exception, result.Error
+}
+
+func (r *eventSeriesRepository) GetExceptionByID(seriesID uint, id uint) (entity.EventSeriesException, error) {
+	var exception entity.EventSeriesException
+	result := r.db.Where("series_id = ?", seriesID).First(&exception, id)
+	return exception, result.Error
+}
+
+func (r *eventSeriesRepository) GetExceptionStarts(seriesID uint) ([]time.Time, error) {
+	var starts []time.Time
+	result := r.db.Model(&entity.EventSeriesException{}).Where("series_id = ?", seriesID).Pluck("occurrence_start", &starts)
+	return starts, result.Error
+}
+
+func (r *eventSeriesRepository) DeleteException(id uint) error {
+	return r.db.Delete(&entity.EventSeriesException{}, id).Error
+}
+
+func (r *eventSeriesRepository) DeleteExceptionByStart(seriesID uint, start time.Time) error {
+	return r.db.Where("series_id = ? AND occurrence_start = ?", seriesID, start).Delete(&entity.EventSeriesException{}).Error
+}
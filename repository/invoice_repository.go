@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepository interface {
+	GetInvoiceByOrderID(orderID uint) (entity.Invoice, error)
+	CreateInvoice(invoice entity.Invoice) (entity.Invoice, error)
+	NextSequence(year int) (int, error)
+}
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+func (r *invoiceRepository) GetInvoiceByOrderID(orderID uint) (entity.Invoice, error) {
+	var invoice entity.Invoice
+	result := r.db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("order_id = ?", orderID).First(&invoice)
+	return invoice, result.Error
+}
+
+func (r *invoiceRepository) CreateInvoice(invoice entity.Invoice) (entity.Invoice, error) {
+	result := r.db.Create(&invoice)
+	return invoice, result.Error
+}
+
+// NextSequence menaikkan nomor urut tahun tersebut dan mengembalikannya. Harus dipanggil di dalam transaksi:
+// baris urutan tetap terkunci sampai commit, dan nomor ikut dibatalkan jika transaksi gagal sehingga tidak ada celah.
+func (r *invoiceRepository) NextSequence(year int) (int, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"last_number": gorm.Expr("last_number + 1")}),
+	}).Create(&entity.InvoiceSequence{Year: year, LastNumber: 1}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var sequence entity.InvoiceSequence
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&sequence, "year = ?", year).Error; err != nil {
+		return 0, err
+	}
+	return sequence.LastNumber, nil
+}
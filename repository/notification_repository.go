@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	CreateNotification(notification entity.Notification) (entity.Notification, error)
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateNotification(notification entity.Notification) (entity.Notification, error) {
+	result := r.db.Create(&notification)
+	return notification, result.Error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepository interface {
+	CreateOrder(order entity.Order) (entity.Order, error)
+	GetOrderByID(id uint) (entity.Order, error)
+	GetOrderByIDForUpdate(id uint) (entity.Order, error)
+	GetOrdersByUserID(userID uint, page int, size int) ([]entity.Order, int64, error)
+	UpdateOrderStatus(id uint, status string) error
+	UpdateOrderPaymentIntent(id uint, intentID string) error
+	UpdatePendingOrderStatusByPaymentIntentID(intentID string, status string) error
+	GetOrderByPaymentIntentID(intentID string) (entity.Order, error)
+	GetOverdueOrderIDs(now time.Time, limit int) ([]uint, error)
+}
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+func (r *orderRepository) CreateOrder(order entity.Order) (entity.Order, error) {
+	// Tiket dibuat terpisah lalu dihubungkan ke order
+	result := r.db.Omit("Tickets").Create(&order)
+	return order, result.Error
+}
+
+func (r *orderRepository) GetOrderByID(id uint) (entity.Order, error) {
+	var order entity.Order
+	result := r.db.Preload("Tickets.Items").First(&order, id)
+	return order, result.Error
+}
+
+// GetOrderByIDForUpdate mengunci order beserta tiketnya sampai transaksi selesai
+func (r *orderRepository) GetOrderByIDForUpdate(id uint) (entity.Order, error) {
+	var order entity.Order
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Tickets").First(&order, id)
+	return order, result.Error
+}
+
+func (r *orderRepository) GetOrdersByUserID(userID uint, page int, size int) ([]entity.Order, int64, error) {
+	var orders []entity.Order
+	var totalItems int64
+
+	query := r.db.Model(&entity.Order{}).Where("user_id = ?", userID)
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * size
+	result := query.Preload("Tickets").Order("id DESC").Offset(offset).Limit(size).Find(&orders)
+	return orders, totalItems, result.Error
+}
+
+func (r *orderRepository) UpdateOrderStatus(id uint, status string) error {
+	return r.db.Model(&entity.Order{}).Where("id = ?", id).Update("status", status).Error
+}
+
+func (r *orderRepository) UpdateOrderPaymentIntent(id uint, intentID string) error {
+	return r.db.Model(&entity.Order{}).Where("id = ?", id).Update("payment_intent_id", intentID).Error
+}
+
+// UpdatePendingOrderStatusByPaymentIntentID menyelesaikan order dari webhook; webhook ganda tidak berefek
+func (r *orderRepository) UpdatePendingOrderStatusByPaymentIntentID(intentID string, status string) error {
+	return r.db.Model(&entity.Order{}).
+		Where("payment_intent_id = ? AND status = ?", intentID, "pending_payment").
+		Update("status", status).Error
+}
+
+func (r *orderRepository) GetOrderByPaymentIntentID(intentID string) (entity.Order, error) {
+	var order entity.Order
+	result := r.db.Where("payment_intent_id = ?", intentID).First(&order)
+	return order, result.Error
+}
+
+// GetOverdueOrderIDs mengambil order pending_payment yang melewati batas pembayarannya
+func (r *orderRepository) GetOverdueOrderIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.Order{}).
+		Where("status = ? AND payment_due_at <= ?", "pending_payment", now).
+		Order("payment_due_at ASC").Limit(limit).Pluck("id", &ids)
+	return ids, result.Error
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+)
+
+type PricingRuleRepository interface {
+	GetRulesByEventID(eventID uint) ([]entity.PricingRule, error)
+	GetRuleByID(eventID uint, id uint) (entity.PricingRule, error)
+	CreateRule(rule entity.PricingRule) (entity.PricingRule, error)
+	UpdateRule(rule entity.PricingRule) (entity.PricingRule, error)
+	DeleteRule(id uint) error
+	IsRuleUsed(id uint) (bool, error)
+}
+
+type pricingRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewPricingRuleRepository(db *gorm.DB) PricingRuleRepository {
+	return &pricingRuleRepository{db: db}
+}
+
+func (r *pricingRuleRepository) GetRulesByEventID(eventID uint) ([]entity.PricingRule, error) {
+	var rules []entity.PricingRule
+	result := r.db.Where("event_id = ?", eventID).Order("priority DESC, id ASC").Find(&rules)
+	return rules, result.Error
+}
+
+func (r *pricingRuleRepository) GetRuleByID(eventID uint, id uint) (entity.PricingRule, error) {
+	var rule entity.PricingRule
+	result := r.db.Where("event_id = ?", eventID).First(&rule, id)
+	return rule, result.Error
+}
+
+func (r *pricingRuleRepository) CreateRule(rule entity.PricingRule) (entity.PricingRule, error) {
+	result := r.db.Create(&rule)
+	return rule, result.Error
+}
+
+// UpdateRule menyimpan semua field yang bisa diubah; nilai nol (mis. is_active=false) ikut disimpan
+func (r *pricingRuleRepository) UpdateRule(rule entity.PricingRule) (entity.PricingRule, error) {
+	result := r.db.Model(&entity.PricingRule{ID: rule.ID}).
+		Select("TicketTypeID", "Name", "RuleType", "AdjustmentType", "Value", "adjustment_amount", "adjustment_currency", "ValidUntil", "SoldThreshold",
+			"HoursBeforeStart", "Priority", "IsActive").
+		Updates(rule)
+	if result.Error != nil {
+		return entity.PricingRule{}, result.Error
+	}
+	return r.GetRuleByID(rule.EventID, rule.ID)
+}
+
+func (r *pricingRuleRepository) DeleteRule(id uint) error {
+	return r.db.Delete(&entity.PricingRule{}, id).Error
+}
+
+// IsRuleUsed memeriksa apakah aturan sudah menentukan harga tiket, termasuk tiket yang sudah di-soft delete
+func (r *pricingRuleRepository) IsRuleUsed(id uint) (bool, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&entity.Ticket{}).Where("pricing_rule_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoCodeRepository interface {
+	GetAllPromoCodes() ([]entity.PromoCode, error)
+	GetPromoCodeByID(id uint) (entity.PromoCode, error)
+	GetPromoCodeByCodeForUpdate(code string) (entity.PromoCode, error)
+	IsPromoCodeUnique(code string, excludeID uint) (bool, error)
+	CreatePromoCode(promo entity.PromoCode) (entity.PromoCode, error)
+	UpdatePromoCode(promo entity.PromoCode) (entity.PromoCode, error)
+	CountRedemptions(promoCodeID uint) (int64, error)
+	CountUserRedemptions(promoCodeID uint, userID uint) (int64, error)
+	CreateRedemption(redemption entity.PromoRedemption) (entity.PromoRedemption, error)
+	GetRedemptionStats() ([]entity.PromoRedemptionStats, error)
+	GetRedemptionsByPromoCodeID(promoCodeID uint, page int, size int) ([]entity.PromoRedemption, int64, error)
+}
+
+type promoCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewPromoCodeRepository(db *gorm.DB) PromoCodeRepository {
+	return &promoCodeRepository{db: db}
+}
+
+func (r *promoCodeRepository) GetAllPromoCodes() ([]entity.PromoCode, error) {
+	var promos []entity.PromoCode
+	result := r.db.Order("id DESC").Find(&promos)
+	return promos, result.Error
+}
+
+func (r *promoCodeRepository) GetPromoCodeByID(id uint) (entity.PromoCode, error) {
+	var promo entity.PromoCode
+	result := r.db.First(&promo, id)
+	return promo, result.Error
+}
+
+// GetPromoCodeByCodeForUpdate mengunci kode promo agar batas pemakaian tidak terlampaui oleh pembelian paralel
+func (r *promoCodeRepository) GetPromoCodeByCodeForUpdate(code string) (entity.PromoCode, error) {
+	var promo entity.PromoCode
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&promo)
+	return promo, result.Error
+}
+
+func (r *promoCodeRepository) IsPromoCodeUnique(code string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.PromoCode{}).Where("code = ? AND id <> ?", code, excludeID).Count(&count).Error
+	return count == 0, err
+}
+
+func (r *promoCodeRepository) CreatePromoCode(promo entity.PromoCode) (entity.PromoCode, error) {
+	result := r.db.Create(&promo)
+	return promo, result.Error
+}
+
+// UpdatePromoCode menyimpan semua field yang bisa diubah; nilai nol (mis. is_active=false) ikut disimpan
+func (r *promoCodeRepository) UpdatePromoCode(promo entity.PromoCode) (entity.PromoCode, error) {
+	result := r.db.Model(&entity.PromoCode{ID: promo.ID}).
+		Select("Description", "DiscountType", "Value", "discount_amount", "discount_currency", "BuyQuantity", "GetQuantity", "EventID", "TicketTypeID",
+			"StartsAt", "EndsAt", "MaxUses", "MaxUsesPerUser", "IsActive").
+		Updates(promo)
+	if result.Error != nil {
+		return entity.PromoCode{}, result.Error
+	}
+	return r.GetPromoCodeByID(promo.ID)
+}
+
+// CountRedemptions menghitung pemakaian kode; order yang pembayarannya gagal tidak dihitung
+func (r *promoCodeRepository) CountRedemptions(promoCodeID uint) (int64, error) {
+	var count int64
+	err := r.activeRedemptions().Where("promo_redemptions.promo_code_id = ?", promoCodeID).Count(&count).Error
+	return count, err
+}
+
+func (r *promoCodeRepository) CountUserRedemptions(promoCodeID uint, userID uint) (int64, error) {
+	var count int64
+	err := r.activeRedemptions().
+		Where("promo_redemptions.promo_code_id = ? AND promo_redemptions.user_id = ?", promoCodeID, userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *promoCodeRepository) activeRedemptions() *gorm.DB {
+	return r.db.Model(&entity.PromoRedemption{}).
+		Joins("JOIN orders ON orders.id = promo_redemptions.order_id").
+		Where("orders.status <> ?", "payment_failed")
+}
+
+func (r *promoCodeRepository) CreateRedemption(redemption entity.PromoRedemption) (entity.PromoRedemption, error) {
+	result := r.db.Create(&redemption)
+	return redemption, result.Error
+}
+
+// GetRedemptionStats meringkas pemakaian setiap kode promo dari order yang dibayar dan yang masih menunggu pembayaran,
+// satu baris per kode dan mata uang order; kode yang belum pernah dipakai tidak memiliki mata uang
+func (r *promoCodeRepository) GetRedemptionStats() ([]entity.PromoRedemptionStats, error) {
+	var stats []entity.PromoRedemptionStats
+	result := r.db.Model(&entity.PromoCode{}).
+		Select("promo_codes.id AS promo_code_id, promo_codes.code, promo_codes.discount_type, " +
+			"COUNT(CASE WHEN orders.status = 'paid' THEN 1 END) AS redemptions, " +
+			"COUNT(CASE WHEN orders.status = 'pending_payment' THEN 1 END) AS pending_orders, " +
+			"COUNT(DISTINCT CASE WHEN orders.status = 'paid' THEN promo_redemptions.user_id END) AS unique_users, " +
+			"COALESCE(SUM(CASE WHEN orders.status = 'paid' THEN promo_redemptions.discount_amount END), 0) AS total_discount_amount, " +
+			"COALESCE(orders.total_currency, '') AS total_discount_currency, " +
+			"COALESCE(SUM(CASE WHEN orders.status = 'paid' THEN orders.total_amount END), 0) AS revenue_amount, " +
+			"COALESCE(orders.total_currency, '') AS revenue_currency").
+		Joins("LEFT JOIN promo_redemptions ON promo_redemptions.promo_code_id = promo_codes.id").
+		Joins("LEFT JOIN orders ON orders.id = promo_redemptions.order_id").
+		Group("promo_codes.id, orders.total_currency").
+		Order("promo_codes.id DESC, orders.total_currency ASC").
+		Scan(&stats)
+	return stats, result.Error
+}
+
+func (r *promoCodeRepository) GetRedemptionsByPromoCodeID(promoCodeID uint, page int, size int) ([]entity.PromoRedemption, int64, error) {
+	var redemptions []entity.PromoRedemption
+	var totalItems int64
+
+	query := r.db.Model(&entity.PromoRedemption{}).Where("promo_code_id = ?", promoCodeID)
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * size
+	result := query.Order("id DESC").Limit(size).Offset(offset).Find(&redemptions)
+	return redemptions, totalItems, result.Error
+}
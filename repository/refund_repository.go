@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefundRepository interface {
+	CreateRefund(refund entity.Refund) (entity.Refund, error)
+	GetRefundsByTicketID(ticketID uint) ([]entity.Refund, error)
+	GetRefundByID(id uint) (entity.Refund, error)
+	SumRefundedByOrderID(orderID uint) (int64, error)
+	UpdateRefundResult(refund entity.Refund) error
+	GetRetryableRefundIDs(before time.Time, maxAttempts int, limit int) ([]uint, error)
+}
+
+type refundRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) RefundRepository {
+	return &refundRepository{db: db}
+}
+
+func (r *refundRepository) CreateRefund(refund entity.Refund) (entity.Refund, error) {
+	result := r.db.Create(&refund)
+	return refund, result.Error
+}
+
+func (r *refundRepository) GetRefundsByTicketID(ticketID uint) ([]entity.Refund, error) {
+	var refunds []entity.Refund
+	result := r.db.Where("ticket_id = ?", ticketID).Order("created_at ASC").Find(&refunds)
+	return refunds, result.Error
+}
+
+func (r *refundRepository) GetRefundByID(id uint) (entity.Refund, error) {
+	var refund entity.Refund
+	result := r.db.First(&refund, id)
+	return refund, result.Error
+}
+
+// SumRefundedByOrderID menjumlahkan refund (dalam satuan terkecil mata uang order) untuk semua tiket order
+func (r *refundRepository) SumRefundedByOrderID(orderID uint) (int64, error) {
+	var total int64
+	result := r.db.Model(&entity.Refund{}).
+		Joins("JOIN tickets ON tickets.id = refunds.ticket_id").
+		Where("tickets.order_id = ?", orderID).
+		Select("COALESCE(SUM(refunds.refund_amount), 0)").
+		Scan(&total)
+	return total, result.Error
+}
+
+// UpdateRefundResult menyimpan hasil pengiriman refund ke provider
+func (r *refundRepository) UpdateRefundResult(refund entity.Refund) error {
+	return r.db.Model(&entity.Refund{}).Where("id = ?", refund.ID).Updates(map[string]interface{}{
+		"status":             refund.Status,
+		"attempts":           refund.Attempts,
+		"failure_reason":     refund.FailureReason,
+		"provider_refund_id": refund.ProviderRefundID,
+	}).Error
+}
+
+// GetRetryableRefundIDs mengambil refund yang tertahan pending atau gagal dan belum melewati batas percobaan.
+// Hanya baris yang tidak berubah sejak before yang diambil agar tidak bertabrakan dengan pengiriman yang sedang berjalan.
+func (r *refundRepository) GetRetryableRefundIDs(before time.Time, maxAttempts int, limit int) ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.Refund{}).
+		Where("status IN ? AND attempts < ? AND updated_at <= ?", []string{"pending", "failed"}, maxAttempts, before).
+		Order("id ASC").Limit(limit).Pluck("id", &ids)
+	return ids, result.Error
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReservationRepository interface {
+	GetReservationByID(id uint) (entity.Reservation, error)
+	GetReservationByIDForUpdate(id uint) (entity.Reservation, error)
+	CreateReservation(reservation entity.Reservation) (entity.Reservation, error)
+	UpdateReservationStatus(id uint, status string, ticketID *uint) error
+	GetExpiredReservationIDs(now time.Time, limit int) ([]uint, error)
+	GetHeldReservationIDsByEventID(eventID uint, limit int) ([]uint, error)
+	SumHeldQuantity(userID uint, eventID uint) (int64, error)
+}
+
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationRepository(db *gorm.DB) ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+func (r *reservationRepository) GetReservationByID(id uint) (entity.Reservation, error) {
+	var reservation entity.Reservation
+	result := r.db.First(&reservation, id)
+	return reservation, result.Error
+}
+
+// GetReservationByIDForUpdate mengunci baris reservasi sampai transaksi selesai
+func (r *reservationRepository) GetReservationByIDForUpdate(id uint) (entity.Reservation, error) {
+	var reservation entity.Reservation
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&reservation, id)
+	return reservation, result.Error
+}
+
+func (r *reservationRepository) CreateReservation(reservation entity.Reservation) (entity.Reservation, error) {
+	result := r.db.Create(&reservation)
+	return reservation, result.Error
+}
+
+func (r *reservationRepository) UpdateReservationStatus(id uint, status string, ticketID *uint) error {
+	result := r.db.Model(&entity.Reservation{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":    status,
+		"ticket_id": ticketID,
+	})
+	return result.Error
+}
+
+// GetExpiredReservationIDs mengambil reservasi berstatus held yang sudah melewati batas waktu
+func (r *reservationRepository) GetExpiredReservationIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.Reservation{}).
+		Where("status = ? AND expires_at <= ?", "held", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Pluck("id", &ids)
+	return ids, result.Error
+}
+
+// GetHeldReservationIDsByEventID mengambil reservasi yang masih menahan kursi event, mis. saat event dibatalkan
+func (r *reservationRepository) GetHeldReservationIDsByEventID(eventID uint, limit int) ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.Reservation{}).
+		Where("event_id = ? AND status = ?", eventID, "held").
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids)
+	return ids, result.Error
+}
+
+// SumHeldQuantity menjumlahkan kursi yang masih ditahan reservasi aktif milik user pada event
+func (r *reservationRepository) SumHeldQuantity(userID uint, eventID uint) (int64, error) {
+	var total int64
+	result := r.db.Model(&entity.Reservation{}).
+		Where("user_id = ? AND event_id = ? AND status = ?", userID, eventID, "held").
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&total)
+	return total, result.Error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SchedulerLockRepository interface {
+	AcquireLock(name string, owner string, ttl time.Duration, now time.Time) (bool, error)
+}
+
+type schedulerLockRepository struct {
+	db *gorm.DB
+}
+
+func NewSchedulerLockRepository(db *gorm.DB) SchedulerLockRepository {
+	return &schedulerLockRepository{db: db}
+}
+
+// AcquireLock mengambil atau memperpanjang lock; lock milik replika lain hanya bisa diambil setelah kedaluwarsa
+func (r *schedulerLockRepository) AcquireLock(name string, owner string, ttl time.Duration, now time.Time) (bool, error) {
+	result := r.db.Model(&entity.SchedulerLock{}).
+		Where("name = ? AND (owner = ? OR expires_at < ?)", name, owner, now).
+		Updates(map[string]interface{}{
+			"owner":      owner,
+			"expires_at": now.Add(ttl),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// Baris lock belum ada; jika replika lain menyisipkannya lebih dulu, insert gagal karena primary key
+	var existing int64
+	if err := r.db.Model(&entity.SchedulerLock{}).Where("name = ?", name).Count(&existing).Error; err != nil {
+		return false, err
+	}
+	if existing > 0 {
+		return false, nil
+	}
+	if err := r.db.Create(&entity.SchedulerLock{Name: name, Owner: owner, ExpiresAt: now.Add(ttl)}).Error; err != nil {
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GateCheckInCount adalah jumlah tiket dan kursi yang sudah check-in di satu gerbang
+type GateCheckInCount struct {
+	GateID  string `json:"gate_id"`
+	Tickets int64  `json:"tickets"`
+	Seats   int64  `json:"seats"`
+}
+
+type TicketItemRepository interface {
+	GetItemsByTicketID(ticketID uint) ([]entity.TicketItem, error)
+	GetItemsByTicketIDForUpdate(ticketID uint) ([]entity.TicketItem, error)
+	GetItemByIDForUpdate(id uint) (entity.TicketItem, error)
+	GetItemByCode(code string) (entity.TicketItem, error)
+	UpdateItemStatus(id uint, status string) error
+	UpdateItemHolder(id uint, holderName string, holderEmail string) error
+	ReassignItem(id uint, code string) error
+	MoveItem(id uint, ticketID uint, code string) error
+	MarkItemCheckedIn(id uint, gateID string, staffID uint, at time.Time) error
+	GetCheckInCounts(eventID uint) ([]GateCheckInCount, error)
+	CountCheckedInTickets(eventID uint) (int64, error)
+}
+
+type ticketItemRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketItemRepository(db *gorm.DB) TicketItemRepository {
+	return &ticketItemRepository{db: db}
+}
+
+func (r *ticketItemRepository) GetItemsByTicketID(ticketID uint) ([]entity.TicketItem, error) {
+	var items []entity.TicketItem
+	result := r.db.Where("ticket_id = ?", ticketID).Order("id ASC").Find(&items)
+	return items, result.Error
+}
+
+// GetItemsByTicketIDForUpdate mengambil dan mengunci semua kursi dalam satu tiket
+func (r *ticketItemRepository) GetItemsByTicketIDForUpdate(ticketID uint) ([]entity.TicketItem, error) {
+	var items []entity.TicketItem
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("ticket_id = ?", ticketID).Order("id ASC").Find(&items)
+	return items, result.Error
+}
+
+// GetItemByIDForUpdate mengunci baris kursi sampai transaksi selesai
+func (r *ticketItemRepository) GetItemByIDForUpdate(id uint) (entity.TicketItem, error) {
+	var item entity.TicketItem
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id)
+	return item, result.Error
+}
+
+// GetItemByCode mencari kursi berdasarkan kode verifikasinya
+func (r *ticketItemRepository) GetItemByCode(code string) (entity.TicketItem, error) {
+	var item entity.TicketItem
+	result := r.db.Where("code = ?", code).First(&item)
+	return item, result.Error
+}
+
+func (r *ticketItemRepository) UpdateItemStatus(id uint, status string) error {
+	result := r.db.Model(&entity.TicketItem{}).Where("id = ?", id).Update("status", status)
+	return result.Error
+}
+
+func (r *ticketItemRepository) UpdateItemHolder(id uint, holderName string, holderEmail string) error {
+	result := r.db.Model(&entity.TicketItem{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"holder_name":  holderName,
+		"holder_email": holderEmail,
+	})
+	return result.Error
+}
+
+// ReassignItem mengganti kode kursi dan mengosongkan data pemegang setelah tiket dipindahtangankan
+func (r *ticketItemRepository) ReassignItem(id uint, code string) error {
+	result := r.db.Model(&entity.TicketItem{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"code":         code,
+		"holder_name":  "",
+		"holder_email": "",
+	})
+	return result.Error
+}
+
+// MoveItem memindahkan kursi ke tiket lain dengan kode baru dan data pemegang dikosongkan
+func (r *ticketItemRepository) MoveItem(id uint, ticketID uint, code string) error {
+	result := r.db.Model(&entity.TicketItem{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"ticket_id":    ticketID,
+		"code":         code,
+		"holder_name":  "",
+		"holder_email": "",
+	})
+	return result.Error
+}
+
+func (r *ticketItemRepository) MarkItemCheckedIn(id uint, gateID string, staffID uint, at time.Time) error {
+	result := r.db.Model(&entity.TicketItem{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"checked_in_at": at,
+		"checked_in_by": staffID,
+		"gate_id":       gateID,
+	})
+	return result.Error
+}
+
+// GetCheckInCounts menghitung tiket dan kursi yang sudah check-in per gerbang.
+// Tiket yang kursinya masuk lewat beberapa gerbang terhitung di setiap gerbang tersebut.
+func (r *ticketItemRepository) GetCheckInCounts(eventID uint) ([]GateCheckInCount, error) {
+	var counts []GateCheckInCount
+	result := r.db.Model(&entity.TicketItem{}).
+		Select("gate_id, COUNT(DISTINCT ticket_id) AS tickets, COUNT(*) AS seats").
+		Where("event_id = ? AND checked_in_at IS NOT NULL", eventID).
+		Group("gate_id").
+		Order("gate_id").
+		Scan(&counts)
+	return counts, result.Error
+}
+
+// CountCheckedInTickets menghitung tiket yang minimal satu kursinya sudah check-in, di gerbang mana pun
+func (r *ticketItemRepository) CountCheckedInTickets(eventID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&entity.TicketItem{}).
+		Where("event_id = ? AND checked_in_at IS NOT NULL", eventID).
+		Distinct("ticket_id").
+		Count(&count)
+	return count, result.Error
+}
@@ -2,17 +2,37 @@ package repository
 
 import (
     "eventix/entity"
+    "eventix/money"
+    "time"
     "gorm.io/gorm"
+    "gorm.io/gorm/clause"
 )
 
 type TicketRepository interface {
     GetAllTickets(page int, size int) ([]entity.Ticket, error)
     GetTicketsByUserID(userID uint, page int, size int) ([]entity.Ticket, int64, error)
     GetTicketByID(id uint) (entity.Ticket, error)
+    GetTicketByIDForUpdate(id uint) (entity.Ticket, error)
     CreateTicket(ticket entity.Ticket) (entity.Ticket, error)
     UpdateTicket(ticket entity.Ticket) (entity.Ticket, error)
     UpdateTicketStatus(id uint, status string) error
-    GetSummaryReport(page int, size int) ([]entity.Ticket, int64, error) // Update untuk mendukung pagination
+    UpdateTicketPaymentIntent(id uint, intentID string) error
+    AssignTicketsToOrder(ids []uint, orderID uint) error
+    ApplyTicketDiscount(id uint, price money.Money, discount money.Money, promoCodeID uint) error
+    UpdateTicketOwner(id uint, userID uint, code string) error
+    UpdateTicketQuantity(id uint, quantity int, price money.Money, discount money.Money) error
+    GetTicketsByPaymentIntentID(intentID string) ([]entity.Ticket, error)
+    GetTicketByCode(code string) (entity.Ticket, error)
+    CountUserActiveSeats(userID uint, eventID uint) (int64, error)
+    CountOpenTicketsByEventID(eventID uint) (int64, error)
+    GetOpenTicketIDsByEventID(eventID uint, afterID uint, limit int) ([]uint, error)
+    DeleteTicket(id uint) error
+    GetDeletedTickets(page int, size int) ([]entity.Ticket, int64, error)
+    GetDeletedTicketByID(id uint) (entity.Ticket, error)
+    RestoreTicket(id uint) error
+    PurgeDeletedTickets(before time.Time) (int64, error)
+    GetSummaryReport(page int, size int) ([]entity.Ticket, int64, error)
+    GetRevenueByCurrency(eventID *uint) ([]money.Money, error) // Update untuk mendukung pagination
     GetEventReport(eventID uint, page int, size int) ([]entity.Ticket, int64, error) // Update untuk mendukung pagination
     SearchTickets(status string) ([]entity.Ticket, error)
     GetPaginatedTickets(page int, size int) ([]entity.Ticket, error)
@@ -38,7 +58,14 @@ func (r *ticketRepository) GetAllTickets(page int, size int) ([]entity.Ticket, e
 
 func (r *ticketRepository) GetTicketByID(id uint) (entity.Ticket, error) {
     var ticket entity.Ticket
-    result := r.db.First(&ticket, id)
+    result := r.db.Preload("Items").First(&ticket, id)
+    return ticket, result.Error
+}
+
+// GetTicketByIDForUpdate mengunci baris tiket sampai transaksi selesai
+func (r *ticketRepository) GetTicketByIDForUpdate(id uint) (entity.Ticket, error) {
+    var ticket entity.Ticket
+    result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ticket, id)
     return ticket, result.Error
 }
 
@@ -70,6 +97,74 @@ func (r *ticketRepository) UpdateTicketStatus(id uint, status string) error {
     return result.Error
 }
 
+func (r *ticketRepository) UpdateTicketPaymentIntent(id uint, intentID string) error {
+    result := r.db.Model(&entity.Ticket{}).Where("id = ?", id).Update("payment_intent_id", intentID)
+    return result.Error
+}
+
+func (r *ticketRepository) AssignTicketsToOrder(ids []uint, orderID uint) error {
+    result := r.db.Model(&entity.Ticket{}).Where("id IN ?", ids).Update("order_id", orderID)
+    return result.Error
+}
+
+// ApplyTicketDiscount menyimpan harga setelah diskon beserta kode promo yang memberi potongan
+func (r *ticketRepository) ApplyTicketDiscount(id uint, price money.Money, discount money.Money, promoCodeID uint) error {
+    result := r.db.Model(&entity.Ticket{}).Where("id = ?", id).Updates(map[string]interface{}{
+        "price_amount":      price.Amount,
+        "price_currency":    price.Currency,
+        "discount_amount":   discount.Amount,
+        "discount_currency": discount.Currency,
+        "promo_code_id":     promoCodeID,
+    })
+    return result.Error
+}
+
+// UpdateTicketOwner memindahkan tiket ke pemilik baru sekaligus mengganti kode pemesanannya
+func (r *ticketRepository) UpdateTicketOwner(id uint, userID uint, code string) error {
+    result := r.db.Model(&entity.Ticket{}).Where("id = ?", id).Updates(map[string]interface{}{
+        "user_id": userID,
+        "code":    code,
+    })
+    return result.Error
+}
+
+// UpdateTicketQuantity mengurangi jumlah kursi dan harga tiket setelah sebagian kursinya dipindahkan ke tiket lain
+func (r *ticketRepository) UpdateTicketQuantity(id uint, quantity int, price money.Money, discount money.Money) error {
+    result := r.db.Model(&entity.Ticket{}).Where("id = ?", id).Updates(map[string]interface{}{
+        "quantity":          quantity,
+        "price_amount":      price.Amount,
+        "price_currency":    price.Currency,
+        "discount_amount":   discount.Amount,
+        "discount_currency": discount.Currency,
+    })
+    return result.Error
+}
+
+// CountUserActiveSeats menghitung kursi aktif milik user pada event, termasuk tiket yang menunggu pembayaran
+func (r *ticketRepository) CountUserActiveSeats(userID uint, eventID uint) (int64, error) {
+    var count int64
+    result := r.db.Model(&entity.TicketItem{}).
+        Joins("JOIN tickets ON tickets.id = ticket_items.ticket_id").
+        Where("tickets.user_id = ? AND tickets.event_id = ? AND tickets.deleted_at IS NULL", userID, eventID).
+        Where("tickets.status IN ? AND ticket_items.status = ?", []string{"pending_payment", "purchased"}, "active").
+        Count(&count)
+    return count, result.Error
+}
+
+// GetTicketsByPaymentIntentID mengambil dan mengunci tiket yang dibayar dengan intent tertentu
+func (r *ticketRepository) GetTicketsByPaymentIntentID(intentID string) ([]entity.Ticket, error) {
+    var tickets []entity.Ticket
+    result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("payment_intent_id = ?", intentID).Find(&tickets)
+    return tickets, result.Error
+}
+
+// GetTicketByCode mencari tiket berdasarkan kode pemesanannya
+func (r *ticketRepository) GetTicketByCode(code string) (entity.Ticket, error) {
+    var ticket entity.Ticket
+    result := r.db.Where("code = ?", code).First(&ticket)
+    return ticket, result.Error
+}
+
 func (r *ticketRepository) GetEventReport(eventID uint, page int, size int) ([]entity.Ticket, int64, error) {
 	var tickets []entity.Ticket
 	var totalItems int64
@@ -104,6 +199,19 @@ func (r *ticketRepository) GetSummaryReport(page int, size int) ([]entity.Ticket
 	return tickets, totalItems, result.Error
 }
 
+// GetRevenueByCurrency menjumlahkan harga tiket terbayar per mata uang; eventID kosong berarti semua event
+func (r *ticketRepository) GetRevenueByCurrency(eventID *uint) ([]money.Money, error) {
+	var revenue []money.Money
+	query := r.db.Model(&entity.Ticket{}).
+		Select("price_currency AS currency, SUM(price_amount) AS amount").
+		Where("status = ?", "purchased")
+	if eventID != nil {
+		query = query.Where("event_id = ?", *eventID)
+	}
+	result := query.Group("price_currency").Order("price_currency ASC").Scan(&revenue)
+	return revenue, result.Error
+}
+
 func (r *ticketRepository) SearchTickets(status string) ([]entity.Ticket, error) {
     var tickets []entity.Ticket
     query := r.db
@@ -124,12 +232,31 @@ func (r *ticketRepository) GetPaginatedTickets(page int, size int) ([]entity.Tic
     return tickets, result.Error
 }
 
+// IsTicketSold memeriksa apakah event memiliki tiket yang sudah dibayar atau sedang menunggu pembayaran
 func (r *ticketRepository) IsTicketSold(eventID uint) (bool, error) {
     var count int64
-    err := r.db.Model(&entity.Ticket{}).Where("event_id = ? AND status = ?", eventID, "sold").Count(&count).Error
+    err := r.db.Model(&entity.Ticket{}).Where("event_id = ? AND status IN ?", eventID, []string{"purchased", "pending_payment"}).Count(&count).Error
     return count > 0, err
 }
 
+// CountOpenTicketsByEventID menghitung tiket event yang masih berlaku atau menunggu pembayaran
+func (r *ticketRepository) CountOpenTicketsByEventID(eventID uint) (int64, error) {
+    var count int64
+    err := r.db.Model(&entity.Ticket{}).Where("event_id = ? AND status IN ?", eventID, []string{"purchased", "pending_payment"}).Count(&count).Error
+    return count, err
+}
+
+// GetOpenTicketIDsByEventID mengambil satu batch ID tiket terbuka setelah kursor afterID
+func (r *ticketRepository) GetOpenTicketIDsByEventID(eventID uint, afterID uint, limit int) ([]uint, error) {
+    var ids []uint
+    result := r.db.Model(&entity.Ticket{}).
+        Where("event_id = ? AND id > ? AND status IN ?", eventID, afterID, []string{"purchased", "pending_payment"}).
+        Order("id ASC").
+        Limit(limit).
+        Pluck("id", &ids)
+    return ids, result.Error
+}
+
 func (r *ticketRepository) GetTicketsByUserID(userID uint, page int, size int) ([]entity.Ticket, int64, error) {
     var tickets []entity.Ticket
     var totalItems int64
@@ -143,7 +270,7 @@ func (r *ticketRepository) GetTicketsByUserID(userID uint, page int, size int) (
     }
 
     // Pagination
-    result := query.Offset(offset).Limit(size).Find(&tickets)
+    result := query.Preload("Items").Offset(offset).Limit(size).Find(&tickets)
     return tickets, totalItems, result.Error
 }
 
@@ -175,3 +302,83 @@ func (r *ticketRepository) SearchAndFilterTickets(filters map[string]interface{}
     return tickets, totalItems, result.Error
 }
 
+
+// DeleteTicket melakukan soft delete; tiket masih bisa dipulihkan sampai dibersihkan job purge
+func (r *ticketRepository) DeleteTicket(id uint) error {
+    result := r.db.Delete(&entity.Ticket{}, id)
+    return result.Error
+}
+
+func (r *ticketRepository) GetDeletedTickets(page int, size int) ([]entity.Ticket, int64, error) {
+    var tickets []entity.Ticket
+    var totalItems int64
+
+    query := r.db.Unscoped().Model(&entity.Ticket{}).Where("deleted_at IS NOT NULL")
+    if err := query.Count(&totalItems).Error; err != nil {
+        return nil, 0, err
+    }
+
+    offset := (page - 1) * size
+    result := query.Order("deleted_at DESC").Offset(offset).Limit(size).Find(&tickets)
+    return tickets, totalItems, result.Error
+}
+
+func (r *ticketRepository) GetDeletedTicketByID(id uint) (entity.Ticket, error) {
+    var ticket entity.Ticket
+    result := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&ticket)
+    return ticket, result.Error
+}
+
+func (r *ticketRepository) RestoreTicket(id uint) error {
+    result := r.db.Unscoped().Model(&entity.Ticket{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+    if result.Error == nil && result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return result.Error
+}
+
+// ticketHasRecords bernilai benar jika tiket dirujuk catatan keuangan atau audit yang harus tetap utuh
+const ticketHasRecords = `(tickets.order_id IS NOT NULL OR tickets.promo_code_id IS NOT NULL
+    OR EXISTS (SELECT 1 FROM refunds WHERE refunds.ticket_id = tickets.id)
+    OR EXISTS (SELECT 1 FROM invoice_lines WHERE invoice_lines.ticket_id = tickets.id)
+    OR EXISTS (SELECT 1 FROM ticket_transfers WHERE ticket_transfers.ticket_id = tickets.id OR ticket_transfers.to_ticket_id = tickets.id))`
+
+// PurgeDeletedTickets menghapus permanen tiket yang sudah di-soft delete sebelum batas retensi beserta kursinya.
+// Tiket yang masih dirujuk order, kode promo, refund, invoice atau transfer tidak dihapus; data pemegang kursinya
+// dikosongkan dan barisnya tetap disimpan agar riwayat keuangan tidak kehilangan rujukan.
+func (r *ticketRepository) PurgeDeletedTickets(before time.Time) (int64, error) {
+    var retainedIDs []uint
+    err := r.db.Unscoped().Model(&entity.Ticket{}).
+        Where("deleted_at < ?", before).
+        Where(ticketHasRecords).
+        Pluck("id", &retainedIDs).Error
+    if err != nil {
+        return 0, err
+    }
+    if len(retainedIDs) > 0 {
+        err := r.db.Model(&entity.TicketItem{}).
+            Where("ticket_id IN ? AND (holder_name <> '' OR holder_email <> '')", retainedIDs).
+            Updates(map[string]interface{}{"holder_name": "", "holder_email": ""}).Error
+        if err != nil {
+            return 0, err
+        }
+    }
+
+    var ids []uint
+    err = r.db.Unscoped().Model(&entity.Ticket{}).
+        Where("deleted_at < ?", before).
+        Where("NOT " + ticketHasRecords).
+        Pluck("id", &ids).Error
+    if err != nil {
+        return 0, err
+    }
+    if len(ids) == 0 {
+        return 0, nil
+    }
+
+    if err := r.db.Where("ticket_id IN ?", ids).Delete(&entity.TicketItem{}).Error; err != nil {
+        return 0, err
+    }
+    result := r.db.Unscoped().Where("id IN ?", ids).Delete(&entity.Ticket{})
+    return result.RowsAffected, result.Error
+}
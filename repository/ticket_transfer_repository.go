@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TicketTransferRepository interface {
+	CreateTransfer(transfer entity.TicketTransfer) (entity.TicketTransfer, error)
+	GetTransferByIDForUpdate(id uint) (entity.TicketTransfer, error)
+	GetTransfersByTicketID(ticketID uint) ([]entity.TicketTransfer, error)
+	GetPendingTransferByTicketID(ticketID uint) (entity.TicketTransfer, error)
+	GetPendingTransfersForUser(userID uint) ([]entity.TicketTransfer, error)
+	UpdateTransferStatus(id uint, status string, respondedAt time.Time) error
+	UpdateTransferTargetTicket(id uint, toTicketID uint) error
+}
+
+type ticketTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketTransferRepository(db *gorm.DB) TicketTransferRepository {
+	return &ticketTransferRepository{db: db}
+}
+
+func (r *ticketTransferRepository) CreateTransfer(transfer entity.TicketTransfer) (entity.TicketTransfer, error) {
+	result := r.db.Create(&transfer)
+	return transfer, result.Error
+}
+
+// GetTransferByIDForUpdate mengunci baris transfer sampai transaksi selesai
+func (r *ticketTransferRepository) GetTransferByIDForUpdate(id uint) (entity.TicketTransfer, error) {
+	var transfer entity.TicketTransfer
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transfer, id)
+	return transfer, result.Error
+}
+
+// GetTransfersByTicketID mengambil transfer tiket, termasuk transfer kursi yang membentuk tiket ini
+func (r *ticketTransferRepository) GetTransfersByTicketID(ticketID uint) ([]entity.TicketTransfer, error) {
+	var transfers []entity.TicketTransfer
+	result := r.db.Where("ticket_id = ? OR to_ticket_id = ?", ticketID, ticketID).Order("created_at ASC").Find(&transfers)
+	return transfers, result.Error
+}
+
+func (r *ticketTransferRepository) GetPendingTransferByTicketID(ticketID uint) (entity.TicketTransfer, error) {
+	var transfer entity.TicketTransfer
+	result := r.db.Where("ticket_id = ? AND status = ?", ticketID, "pending").First(&transfer)
+	return transfer, result.Error
+}
+
+// GetPendingTransfersForUser mengambil transfer masuk maupun keluar milik user yang belum dijawab
+func (r *ticketTransferRepository) GetPendingTransfersForUser(userID uint) ([]entity.TicketTransfer, error) {
+	var transfers []entity.TicketTransfer
+	result := r.db.Where("status = ? AND (to_user_id = ? OR from_user_id = ?)", "pending", userID, userID).
+		Order("created_at DESC").
+		Find(&transfers)
+	return transfers, result.Error
+}
+
+func (r *ticketTransferRepository) UpdateTransferStatus(id uint, status string, respondedAt time.Time) error {
+	result := r.db.Model(&entity.TicketTransfer{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       status,
+		"responded_at": respondedAt,
+	})
+	return result.Error
+}
+
+func (r *ticketTransferRepository) UpdateTransferTargetTicket(id uint, toTicketID uint) error {
+	result := r.db.Model(&entity.TicketTransfer{}).Where("id = ?", id).Update("to_ticket_id", toTicketID)
+	return result.Error
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TicketTypeRepository interface {
+	GetTicketTypesByEventID(eventID uint) ([]entity.TicketType, error)
+	GetTicketTypeByID(id uint) (entity.TicketType, error)
+	GetTicketTypeByIDForUpdate(id uint) (entity.TicketType, error)
+	CreateTicketType(ticketType entity.TicketType) (entity.TicketType, error)
+	UpdateTicketType(ticketType entity.TicketType) (entity.TicketType, error)
+	DeleteTicketType(id uint) error
+	AddSoldCount(id uint, delta int) error
+	AddReservedCount(id uint, delta int) error
+	SumQuotaByEventID(eventID uint, excludeID uint) (int, error)
+}
+
+type ticketTypeRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketTypeRepository(db *gorm.DB) TicketTypeRepository {
+	return &ticketTypeRepository{db: db}
+}
+
+func (r *ticketTypeRepository) GetTicketTypesByEventID(eventID uint) ([]entity.TicketType, error) {
+	var ticketTypes []entity.TicketType
+	result := r.db.Where("event_id = ?", eventID).Order("price_amount ASC").Find(&ticketTypes)
+	return ticketTypes, result.Error
+}
+
+func (r *ticketTypeRepository) GetTicketTypeByID(id uint) (entity.TicketType, error) {
+	var ticketType entity.TicketType
+	result := r.db.First(&ticketType, id)
+	return ticketType, result.Error
+}
+
+// GetTicketTypeByIDForUpdate mengunci baris kategori tiket sampai transaksi selesai
+func (r *ticketTypeRepository) GetTicketTypeByIDForUpdate(id uint) (entity.TicketType, error) {
+	var ticketType entity.TicketType
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ticketType, id)
+	return ticketType, result.Error
+}
+
+func (r *ticketTypeRepository) CreateTicketType(ticketType entity.TicketType) (entity.TicketType, error) {
+	result := r.db.Create(&ticketType)
+	return ticketType, result.Error
+}
+
+func (r *ticketTypeRepository) UpdateTicketType(ticketType entity.TicketType) (entity.TicketType, error) {
+	var existing entity.TicketType
+	if err := r.db.First(&existing, ticketType.ID).Error; err != nil {
+		return entity.TicketType{}, err
+	}
+
+	// Select agar harga 0 dan jendela penjualan kosong tetap tersimpan
+	err := r.db.Model(&existing).
+		Select("name", "price_amount", "price_currency", "quota", "max_per_order", "sales_start_at", "sales_end_at").
+		Updates(ticketType).Error
+	if err != nil {
+		return entity.TicketType{}, err
+	}
+
+	return existing, nil
+}
+
+func (r *ticketTypeRepository) DeleteTicketType(id uint) error {
+	result := r.db.Delete(&entity.TicketType{}, id)
+	return result.Error
+}
+
+// AddSoldCount menambah (delta positif) atau mengurangi (delta negatif) jumlah kursi terjual
+func (r *ticketTypeRepository) AddSoldCount(id uint, delta int) error {
+	result := r.db.Model(&entity.TicketType{}).Where("id = ?", id).Update("sold_count", gorm.Expr("sold_count + ?", delta))
+	return result.Error
+}
+
+// AddReservedCount menambah atau mengurangi jumlah kursi yang sedang ditahan reservasi
+func (r *ticketTypeRepository) AddReservedCount(id uint, delta int) error {
+	result := r.db.Model(&entity.TicketType{}).Where("id = ?", id).Update("reserved_count", gorm.Expr("reserved_count + ?", delta))
+	return result.Error
+}
+
+// SumQuotaByEventID menjumlahkan kuota semua kategori dalam event, kecuali excludeID
+func (r *ticketTypeRepository) SumQuotaByEventID(eventID uint, excludeID uint) (int, error) {
+	var total int
+	query := r.db.Model(&entity.TicketType{}).Where("event_id = ?", eventID)
+	if excludeID > 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Select("COALESCE(SUM(quota), 0)").Scan(&total).Error
+	return total, err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import "gorm.io/gorm"
+
+// Repositories berisi instance repository yang terikat pada satu koneksi/transaksi
+type Repositories struct {
+	Events         EventRepository
+	Tickets        TicketRepository
+	TicketItems    TicketItemRepository
+	Transfers      TicketTransferRepository
+	Waitlist       WaitlistRepository
+	Cancellations  EventCancellationRepository
+	Notifications  NotificationRepository
+	Venues         VenueRepository
+	EventSeats     EventSeatRepository
+	Series         EventSeriesRepository
+	Orders         OrderRepository
+	Invoices       InvoiceRepository
+	Carts          CartRepository
+	PromoCodes     PromoCodeRepository
+	PricingRules   PricingRuleRepository
+	TicketTypes    TicketTypeRepository
+	Reservations   ReservationRepository
+	Refunds        RefundRepository
+	Users          UserRepository
+	TokenBlacklist TokenBlacklistRepository
+}
+
+type TxManager interface {
+	WithinTx(fn func(repos Repositories) error) error
+}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{db: db}
+}
+
+// WithinTx menjalankan fn dalam satu transaksi; commit jika fn mengembalikan nil, rollback jika error
+func (m *txManager) WithinTx(fn func(repos Repositories) error) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		return fn(newRepositories(tx))
+	})
+}
+
+func newRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Events:         NewEventRepository(db),
+		Tickets:        NewTicketRepository(db),
+		TicketItems:    NewTicketItemRepository(db),
+		Transfers:      NewTicketTransferRepository(db),
+		Waitlist:       NewWaitlistRepository(db),
+		Cancellations:  NewEventCancellationRepository(db),
+		Notifications:  NewNotificationRepository(db),
+		Venues:         NewVenueRepository(db),
+		EventSeats:     NewEventSeatRepository(db),
+		Series:         NewEventSeriesRepository(db),
+		Orders:         NewOrderRepository(db),
+		Invoices:       NewInvoiceRepository(db),
+		Carts:          NewCartRepository(db),
+		PromoCodes:     NewPromoCodeRepository(db),
+		PricingRules:   NewPricingRuleRepository(db),
+		TicketTypes:    NewTicketTypeRepository(db),
+		Reservations:   NewReservationRepository(db),
+		Refunds:        NewRefundRepository(db),
+		Users:          NewUserRepository(db),
+		TokenBlacklist: NewTokenBlacklistRepository(db),
+	}
+}
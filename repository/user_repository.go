@@ -2,6 +2,7 @@ package repository
 
 import (
     "eventix/entity"
+    "time"
     "gorm.io/gorm"
 )
 
@@ -10,6 +11,10 @@ type UserRepository interface {
     GetUserByID(id uint) (entity.User, error)
     GetUserByUsername(username string) (entity.User, error)
     UpdateUserRole(userID uint, role string) error
+    DeleteUser(id uint) error
+    GetDeletedUsers(page int, size int) ([]entity.User, int64, error)
+    RestoreUser(id uint) error
+    PurgeDeletedUsers(before time.Time) (int64, error)
 }
 
 type userRepository struct {
@@ -42,3 +47,43 @@ func (r *userRepository) UpdateUserRole(userID uint, role string) error {
     return result.Error
 }
 
+
+// DeleteUser melakukan soft delete; user terhapus tidak lagi ditemukan saat login
+func (r *userRepository) DeleteUser(id uint) error {
+    result := r.db.Delete(&entity.User{}, id)
+    if result.Error == nil && result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return result.Error
+}
+
+func (r *userRepository) GetDeletedUsers(page int, size int) ([]entity.User, int64, error) {
+    var users []entity.User
+    var totalItems int64
+
+    query := r.db.Unscoped().Model(&entity.User{}).Where("deleted_at IS NOT NULL")
+    if err := query.Count(&totalItems).Error; err != nil {
+        return nil, 0, err
+    }
+
+    offset := (page - 1) * size
+    result := query.Order("deleted_at DESC").Offset(offset).Limit(size).Find(&users)
+    return users, totalItems, result.Error
+}
+
+func (r *userRepository) RestoreUser(id uint) error {
+    result := r.db.Unscoped().Model(&entity.User{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+    if result.Error == nil && result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return result.Error
+}
+
+// PurgeDeletedUsers menghapus permanen user terhapus yang melewati batas retensi dan tidak memiliki tiket
+func (r *userRepository) PurgeDeletedUsers(before time.Time) (int64, error) {
+    result := r.db.Unscoped().
+        Where("deleted_at < ?", before).
+        Where("NOT EXISTS (SELECT 1 FROM tickets WHERE tickets.user_id = users.id)").
+        Delete(&entity.User{})
+    return result.RowsAffected, result.Error
+}
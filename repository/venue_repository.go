@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"eventix/entity"
+
+	"gorm.io/gorm"
+)
+
+type VenueRepository interface {
+	GetAllVenues() ([]entity.Venue, error)
+	GetVenueByID(id uint) (entity.Venue, error)
+	GetVenueWithSeatMap(id uint) (entity.Venue, error)
+	CreateVenue(venue entity.Venue) (entity.Venue, error)
+	ReplaceSeatMap(venueID uint, sections []entity.Section) error
+	CountSeatsInUse(venueID uint) (int64, error)
+	GetSeatsByIDs(ids []uint) ([]entity.Seat, error)
+}
+
+type venueRepository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) VenueRepository {
+	return &venueRepository{db: db}
+}
+
+func (r *venueRepository) GetAllVenues() ([]entity.Venue, error) {
+	var venues []entity.Venue
+	result := r.db.Order("name ASC").Find(&venues)
+	return venues, result.Error
+}
+
+func (r *venueRepository) GetVenueByID(id uint) (entity.Venue, error) {
+	var venue entity.Venue
+	result := r.db.First(&venue, id)
+	return venue, result.Error
+}
+
+// GetVenueWithSeatMap mengambil venue beserta seluruh section, row dan kursinya secara berurutan
+func (r *venueRepository) GetVenueWithSeatMap(id uint) (entity.Venue, error) {
+	var venue entity.Venue
+	result := r.db.
+		Preload("Sections", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Sections.Rows", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Sections.Rows.Seats", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		First(&venue, id)
+	return venue, result.Error
+}
+
+func (r *venueRepository) CreateVenue(venue entity.Venue) (entity.Venue, error) {
+	result := r.db.Omit("Sections").Create(&venue)
+	return venue, result.Error
+}
+
+// ReplaceSeatMap menghapus denah lama lalu menyimpan section, row dan kursi baru
+func (r *venueRepository) ReplaceSeatMap(venueID uint, sections []entity.Section) error {
+	var sectionIDs []uint
+	if err := r.db.Model(&entity.Section{}).Where("venue_id = ?", venueID).Pluck("id", &sectionIDs).Error; err != nil {
+		return err
+	}
+	if len(sectionIDs) > 0 {
+		if err := r.db.Where("venue_id = ?", venueID).Delete(&entity.Seat{}).Error; err != nil {
+			return err
+		}
+		if err := r.db.Where("section_id IN ?", sectionIDs).Delete(&entity.SeatRow{}).Error; err != nil {
+			return err
+		}
+		if err := r.db.Where("id IN ?", sectionIDs).Delete(&entity.Section{}).Error; err != nil {
+			return err
+		}
+	}
+
+	// Kursi disimpan per row karena SectionID dan RowID baru diketahui setelah induknya tersimpan
+	for _, section := range sections {
+		rows := section.Rows
+		section.VenueID = venueID
+		section.Rows = nil
+		if err := r.db.Create(&section).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			seats := row.Seats
+			row.SectionID = section.ID
+			row.Seats = nil
+			if err := r.db.Create(&row).Error; err != nil {
+				return err
+			}
+			for i := range seats {
+				seats[i].VenueID = venueID
+				seats[i].SectionID = section.ID
+				seats[i].RowID = row.ID
+			}
+			if len(seats) > 0 {
+				if err := r.db.Create(&seats).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CountSeatsInUse menghitung kursi venue yang sudah diambil di event mana pun
+func (r *venueRepository) CountSeatsInUse(venueID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&entity.EventSeat{}).
+		Joins("JOIN seats ON seats.id = event_seats.seat_id").
+		Where("seats.venue_id = ?", venueID).
+		Count(&count)
+	return count, result.Error
+}
+
+func (r *venueRepository) GetSeatsByIDs(ids []uint) ([]entity.Seat, error) {
+	var seats []entity.Seat
+	result := r.db.Where("id IN ?", ids).Find(&seats)
+	return seats, result.Error
+}
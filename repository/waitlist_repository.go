@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"eventix/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WaitlistRepository interface {
+	CreateEntry(entry entity.WaitlistEntry) (entity.WaitlistEntry, error)
+	GetActiveEntry(eventID uint, userID uint) (entity.WaitlistEntry, error)
+	GetActiveEntryForUpdate(eventID uint, userID uint) (entity.WaitlistEntry, error)
+	GetWaitingEntriesForUpdate(eventID uint, limit int) ([]entity.WaitlistEntry, error)
+	CountWaitingAhead(eventID uint, entryID uint) (int64, error)
+	GetEventIDsWithWaitingEntries() ([]uint, error)
+	UpdateEntryStatus(id uint, status string) error
+	MarkEntryOffered(id uint, reservationID uint, offeredAt time.Time) error
+	UpdateEntryStatusByReservation(reservationID uint, status string) error
+	ExpireWaitingEntriesByEventID(eventID uint) error
+}
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+func (r *waitlistRepository) CreateEntry(entry entity.WaitlistEntry) (entity.WaitlistEntry, error) {
+	result := r.db.Create(&entry)
+	return entry, result.Error
+}
+
+// GetActiveEntry mengambil antrean user yang masih menunggu atau sedang ditawari kursi
+func (r *waitlistRepository) GetActiveEntry(eventID uint, userID uint) (entity.WaitlistEntry, error) {
+	var entry entity.WaitlistEntry
+	result := r.db.Where("event_id = ? AND user_id = ? AND status IN ?", eventID, userID, []string{"waiting", "offered"}).
+		First(&entry)
+	return entry, result.Error
+}
+
+func (r *waitlistRepository) GetActiveEntryForUpdate(eventID uint, userID uint) (entity.WaitlistEntry, error) {
+	var entry entity.WaitlistEntry
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND user_id = ? AND status IN ?", eventID, userID, []string{"waiting", "offered"}).
+		First(&entry)
+	return entry, result.Error
+}
+
+// GetWaitingEntriesForUpdate mengunci antrean terdepan sesuai urutan masuk
+func (r *waitlistRepository) GetWaitingEntriesForUpdate(eventID uint, limit int) ([]entity.WaitlistEntry, error) {
+	var entries []entity.WaitlistEntry
+	result := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ? AND status = ?", eventID, "waiting").
+		Order("id ASC").
+		Limit(limit).
+		Find(&entries)
+	return entries, result.Error
+}
+
+func (r *waitlistRepository) CountWaitingAhead(eventID uint, entryID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&entity.WaitlistEntry{}).
+		Where("event_id = ? AND status = ? AND id < ?", eventID, "waiting", entryID).
+		Count(&count)
+	return count, result.Error
+}
+
+func (r *waitlistRepository) GetEventIDsWithWaitingEntries() ([]uint, error) {
+	var ids []uint
+	result := r.db.Model(&entity.WaitlistEntry{}).
+		Where("status = ?", "waiting").
+		Distinct().
+		Pluck("event_id", &ids)
+	return ids, result.Error
+}
+
+func (r *waitlistRepository) UpdateEntryStatus(id uint, status string) error {
+	result := r.db.Model(&entity.WaitlistEntry{}).Where("id = ?", id).Update("status", status)
+	return result.Error
+}
+
+func (r *waitlistRepository) MarkEntryOffered(id uint, reservationID uint, offeredAt time.Time) error {
+	result := r.db.Model(&entity.WaitlistEntry{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":         "offered",
+		"reservation_id": reservationID,
+		"offered_at":     offeredAt,
+	})
+	return result.Error
+}
+
+// UpdateEntryStatusByReservation menutup tawaran antrean ketika reservasinya dikonfirmasi atau kedaluwarsa
+func (r *waitlistRepository) UpdateEntryStatusByReservation(reservationID uint, status string) error {
+	result := r.db.Model(&entity.WaitlistEntry{}).
+		Where("reservation_id = ? AND status = ?", reservationID, "offered").
+		Update("status", status)
+	return result.Error
+}
+
+// ExpireWaitingEntriesByEventID mengakhiri antrean yang belum mendapat tawaran, mis. saat event dibatalkan
+func (r *waitlistRepository) ExpireWaitingEntriesByEventID(eventID uint) error {
+	result := r.db.Model(&entity.WaitlistEntry{}).
+		Where("event_id = ? AND status = ?", eventID, "waiting").
+		Update("status", "expired")
+	return result.Error
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"eventix/clock"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Locker mengambil lock leader bernama untuk satu pemilik selama ttl
+type Locker interface {
+	AcquireLock(name string, owner string, ttl time.Duration, now time.Time) (bool, error)
+}
+
+// InstanceID mengidentifikasi replika ini sebagai pemilik lock
+func InstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// WithLeaderLock membungkus job agar hanya dijalankan oleh replika yang memegang lock.
+// ttl sebaiknya lebih panjang dari interval job agar leader tetap sama selama masih hidup.
+func WithLeaderLock(locker Locker, clk clock.Clock, name string, owner string, ttl time.Duration, job func() error) func() error {
+	return func() error {
+		acquired, err := locker.AcquireLock(name, owner, ttl, clk.Now())
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		return job()
+	}
+}
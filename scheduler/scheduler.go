@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+)
+
+// Every menjalankan job secara berkala di goroutine terpisah.
+// Fungsi yang dikembalikan menghentikan job tersebut.
+func Every(name string, interval time.Duration, job func() error) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := job(); err != nil {
+					log.Printf("[Scheduler] %s failed: %v\n", name, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/repository"
+	"eventix/ticketcode"
+	"time"
+)
+
+type CheckInService interface {
+	CheckIn(code string, payload string, eventID uint, gateID string, staffID uint) ([]entity.TicketItem, error)
+	GetCheckInStats(eventID uint) (map[string]interface{}, error)
+}
+
+type checkInService struct {
+	txManager repository.TxManager
+	itemRepo  repository.TicketItemRepository
+	eventRepo repository.EventRepository
+	signer    *ticketcode.Signer
+}
+
+func NewCheckInService(txManager repository.TxManager, itemRepo repository.TicketItemRepository, eventRepo repository.EventRepository, signer *ticketcode.Signer) CheckInService {
+	return &checkInService{
+		txManager: txManager,
+		itemRepo:  itemRepo,
+		eventRepo: eventRepo,
+		signer:    signer,
+	}
+}
+
+// CheckIn memvalidasi kursi di gerbang; payload QR diutamakan, kode manual dipakai jika payload kosong
+func (s *checkInService) CheckIn(code string, payload string, eventID uint, gateID string, staffID uint) ([]entity.TicketItem, error) {
+	if payload != "" {
+		payloadEventID, payloadCode, err := s.signer.Verify(payload)
+		if err != nil {
+			return nil, ErrCheckInInvalidPayload
+		}
+		if payloadEventID != eventID {
+			return nil, ErrCheckInWrongEvent
+		}
+		code = payloadCode
+	}
+	if code == "" {
+		return nil, errors.New("code or payload is required")
+	}
+
+	var checkedIn []entity.TicketItem
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Kode kursi diutamakan; kode pemesanan meng-check-in semua kursi aktif dalam tiket
+		ticketID := uint(0)
+		itemID := uint(0)
+		if item, err := repos.TicketItems.GetItemByCode(code); err == nil {
+			ticketID, itemID = item.TicketID, item.ID
+		} else if ticket, err := repos.Tickets.GetTicketByCode(code); err == nil {
+			ticketID = ticket.ID
+		} else {
+			return ErrCheckInTicketNotFound
+		}
+
+		// Kunci tiket lalu kursinya (urutan sama dengan pembatalan) agar pemindaian ganda tidak sama-sama lolos
+		ticket, err := repos.Tickets.GetTicketByIDForUpdate(ticketID)
+		if err != nil {
+			return ErrCheckInTicketNotFound
+		}
+		if ticket.EventID != eventID {
+			return ErrCheckInWrongEvent
+		}
+		if ticket.Status == "cancelled" {
+			return ErrCheckInTicketCancelled
+		}
+		if ticket.Status != "purchased" {
+			return ErrCheckInTicketNotPaid
+		}
+
+		var items []entity.TicketItem
+		if itemID > 0 {
+			item, err := repos.TicketItems.GetItemByIDForUpdate(itemID)
+			if err != nil {
+				return ErrCheckInTicketNotFound
+			}
+			items = []entity.TicketItem{item}
+		} else if items, err = repos.TicketItems.GetItemsByTicketIDForUpdate(ticketID); err != nil {
+			return err
+		}
+
+		// Pilih kursi yang masih aktif dan belum check-in
+		var pending []entity.TicketItem
+		activeCount := 0
+		for _, item := range items {
+			if item.Status != "active" {
+				continue
+			}
+			activeCount++
+			if item.CheckedInAt == nil {
+				pending = append(pending, item)
+			}
+		}
+		if activeCount == 0 {
+			return ErrCheckInTicketCancelled
+		}
+		if len(pending) == 0 {
+			return ErrCheckInDuplicate
+		}
+
+		now := time.Now()
+		for _, item := range pending {
+			if err := repos.TicketItems.MarkItemCheckedIn(item.ID, gateID, staffID, now); err != nil {
+				return err
+			}
+			item.CheckedInAt = &now
+			item.CheckedInBy = &staffID
+			item.GateID = gateID
+			checkedIn = append(checkedIn, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return checkedIn, nil
+}
+
+func (s *checkInService) GetCheckInStats(eventID uint) (map[string]interface{}, error) {
+	event, err := s.eventRepo.GetEventByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	gates, err := s.itemRepo.GetCheckInCounts(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Jumlah tiket dihitung terpisah karena satu tiket bisa masuk lewat beberapa gerbang
+	checkedInTickets, err := s.itemRepo.CountCheckedInTickets(eventID)
+	if err != nil {
+		return nil, err
+	}
+	var checkedInSeats int64
+	for _, gate := range gates {
+		checkedInSeats += gate.Seats
+	}
+
+	return map[string]interface{}{
+		"event_id":           event.ID,
+		"sold_count":         event.SoldCount,
+		"checked_in_tickets": checkedInTickets,
+		"checked_in_seats":   checkedInSeats,
+		"by_gate":            gates,
+	}, nil
+}
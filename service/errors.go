@@ -0,0 +1,46 @@
+package service
+
+import "errors"
+
+// Error yang perlu dibedakan oleh controller untuk menentukan HTTP status
+var (
+	ErrTicketNotFound     = errors.New("ticket not found")
+	ErrTicketForbidden    = errors.New("you are not allowed to access this ticket")
+	ErrTicketItemNotFound = errors.New("ticket item not found")
+
+	ErrCheckInInvalidPayload  = errors.New("ticket payload is invalid or has been tampered with")
+	ErrCheckInTicketNotFound  = errors.New("ticket not found")
+	ErrCheckInWrongEvent      = errors.New("ticket is not valid for this event")
+	ErrCheckInTicketCancelled = errors.New("ticket has been cancelled")
+	ErrCheckInTicketNotPaid   = errors.New("ticket has not been paid")
+	ErrCheckInDuplicate       = errors.New("ticket has already been checked in")
+
+	ErrEventNotAvailable = errors.New("event is not available for sale")
+	ErrSalesNotStarted   = errors.New("ticket sales for this event have not started yet")
+	ErrSalesEnded        = errors.New("ticket sales for this event have ended")
+
+	ErrSeatUnavailable  = errors.New("one or more selected seats are no longer available")
+	ErrCapacityExceeded = errors.New("quantity exceeds event capacity")
+
+	ErrInvalidQuantity  = errors.New("quantity must be greater than zero")
+	ErrBelowMinPerOrder = errors.New("quantity is below the minimum per order for this event")
+	ErrAboveMaxPerOrder = errors.New("quantity exceeds the maximum per order for this event")
+	ErrAboveMaxPerUser  = errors.New("quantity exceeds the maximum tickets per user for this event")
+
+	ErrTransferNotFound   = errors.New("transfer not found")
+	ErrTransferForbidden  = errors.New("you are not allowed to respond to this transfer")
+	ErrTransferNotAllowed = errors.New("tickets for this event cannot be transferred")
+	ErrTransferClosed     = errors.New("transfer window for this event has closed")
+	ErrTransferPending    = errors.New("ticket already has a pending transfer")
+
+	ErrCartEmpty        = errors.New("cart is empty")
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrCurrencyMismatch = errors.New("all tickets in one order must use the same currency")
+	ErrInvoiceNotFound  = errors.New("no invoice has been issued for this ticket's order yet")
+
+	ErrPromoCodeNotFound      = errors.New("promo code not found")
+	ErrPromoCodeNotActive     = errors.New("promo code is not active or has expired")
+	ErrPromoCodeUsedUp        = errors.New("promo code has reached its usage limit")
+	ErrPromoCodeUserLimit     = errors.New("you have reached the usage limit for this promo code")
+	ErrPromoCodeNotApplicable = errors.New("promo code does not apply to the selected tickets")
+)
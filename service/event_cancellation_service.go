@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/repository"
+	"fmt"
+	"time"
+)
+
+type EventCancellationService interface {
+	CancelEvent(eventID uint, adminID uint, reason string) (entity.EventCancellation, error)
+	GetCancellationProgress(eventID uint) (entity.EventCancellation, error)
+	ProcessPendingCancellations() (int, error)
+}
+
+type eventCancellationService struct {
+	txManager repository.TxManager
+	repo      repository.EventCancellationRepository
+	payments  PaymentService
+	batchSize int
+}
+
+func NewEventCancellationService(txManager repository.TxManager, repo repository.EventCancellationRepository, payments PaymentService) EventCancellationService {
+	return &eventCancellationService{
+		txManager: txManager,
+		repo:      repo,
+		payments:  payments,
+		batchSize: 100,
+	}
+}
+
+// CancelEvent menandai event cancelled lalu membuat job pembatalan tiket yang diproses bertahap di background
+func (s *eventCancellationService) CancelEvent(eventID uint, adminID uint, reason string) (entity.EventCancellation, error) {
+	var cancellation entity.EventCancellation
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Kunci event agar tidak ada tiket baru terjual selama status diubah
+		event, err := repos.Events.GetEventByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+		if event.Status == "cancelled" {
+			return errors.New("event has already been cancelled")
+		}
+		if event.Status == "completed" {
+			return errors.New("event has already been completed")
+		}
+
+		event.Status = "cancelled"
+		if _, err := repos.Events.UpdateEvent(entity.Event{ID: event.ID, Status: event.Status}); err != nil {
+			return err
+		}
+
+		total, err := repos.Tickets.CountOpenTicketsByEventID(event.ID)
+		if err != nil {
+			return err
+		}
+
+		cancellation, err = repos.Cancellations.CreateCancellation(entity.EventCancellation{
+			EventID:      event.ID,
+			RequestedBy:  adminID,
+			Reason:       reason,
+			Status:       "running",
+			TotalTickets: total,
+		})
+		return err
+	})
+	if err != nil {
+		return entity.EventCancellation{}, err
+	}
+
+	// Mulai memproses tanpa menunggu scheduler; job yang terputus dilanjutkan scheduler dari kursornya
+	go func() {
+		_ = s.processCancellation(cancellation.ID)
+	}()
+
+	return cancellation, nil
+}
+
+// GetCancellationProgress mengambil progres pembatalan terakhir untuk event
+func (s *eventCancellationService) GetCancellationProgress(eventID uint) (entity.EventCancellation, error) {
+	cancellation, err := s.repo.GetLatestCancellationByEventID(eventID)
+	if err != nil {
+		return entity.EventCancellation{}, errors.New("event has not been cancelled")
+	}
+	return cancellation, nil
+}
+
+// ProcessPendingCancellations dijalankan scheduler untuk melanjutkan job pembatalan yang belum selesai
+func (s *eventCancellationService) ProcessPendingCancellations() (int, error) {
+	ids, err := s.repo.GetUnfinishedCancellationIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := s.processCancellation(id); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+// processCancellation melepas reservasi event, membatalkan tiket per batch sampai kursor habis,
+// lalu mencoba ulang tiket yang gagal
+func (s *eventCancellationService) processCancellation(id uint) error {
+	holdsReleased := false
+	for {
+		var cancellation entity.EventCancellation
+		var ticketIDs []uint
+		err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+			var err error
+			cancellation, err = repos.Cancellations.GetCancellationByIDForUpdate(id)
+			if err != nil || !cancellationUnfinished(cancellation) {
+				return err
+			}
+
+			ticketIDs, err = repos.Tickets.GetOpenTicketIDsByEventID(cancellation.EventID, cancellation.LastTicketID, s.batchSize)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if !cancellationUnfinished(cancellation) {
+			return nil
+		}
+		if !holdsReleased {
+			if err := s.releaseEventHolds(cancellation.EventID); err != nil {
+				return err
+			}
+			holdsReleased = true
+		}
+		if len(ticketIDs) == 0 {
+			return s.retryFailedTickets(cancellation)
+		}
+
+		for _, ticketID := range ticketIDs {
+			refund, cancelErr := s.cancelTicket(cancellation.ID, ticketID)
+			if cancelErr == nil {
+				// Refund dikirim setelah tiket batal di-commit; yang gagal dicoba ulang oleh job refund-retry
+				if _, err := s.payments.SendRefund(refund); err != nil {
+					return err
+				}
+				continue
+			}
+			// Kursor tetap maju agar satu tiket bermasalah tidak menahan batch berikutnya; tiketnya tetap
+			// terbuka dan dicoba ulang setelah kursor habis
+			if err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+				return repos.Cancellations.RecordTicketFailed(cancellation.ID, ticketID, cancelErr.Error())
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// retryFailedTickets mencoba ulang tiket yang masih terbuka di belakang kursor. Job baru selesai bila semuanya
+// berhasil dibatalkan; selama masih ada yang gagal job berstatus completed_with_errors dan diambil lagi oleh scheduler.
+func (s *eventCancellationService) retryFailedTickets(cancellation entity.EventCancellation) error {
+	var failed int64
+	var lastErr error
+	var afterID uint
+	for {
+		var ticketIDs []uint
+		err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+			var err error
+			ticketIDs, err = repos.Tickets.GetOpenTicketIDsByEventID(cancellation.EventID, afterID, s.batchSize)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if len(ticketIDs) == 0 {
+			break
+		}
+		for _, ticketID := range ticketIDs {
+			afterID = ticketID
+			refund, cancelErr := s.cancelTicket(cancellation.ID, ticketID)
+			if cancelErr != nil {
+				failed++
+				lastErr = cancelErr
+				continue
+			}
+			if _, err := s.payments.SendRefund(refund); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.txManager.WithinTx(func(repos repository.Repositories) error {
+		if failed > 0 {
+			return repos.Cancellations.MarkCancellationCompletedWithErrors(cancellation.ID, failed, lastErr.Error())
+		}
+		return repos.Cancellations.MarkCancellationCompleted(cancellation.ID, time.Now())
+	})
+}
+
+// releaseEventHolds melepas reservasi yang masih ditahan untuk event, termasuk tawaran waitlist,
+// lalu mengakhiri antrean yang belum mendapat tawaran
+func (s *eventCancellationService) releaseEventHolds(eventID uint) error {
+	for {
+		var reservationIDs []uint
+		err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+			var err error
+			reservationIDs, err = repos.Reservations.GetHeldReservationIDsByEventID(eventID, s.batchSize)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if len(reservationIDs) == 0 {
+			break
+		}
+
+		for _, reservationID := range reservationIDs {
+			err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+				reservation, err := repos.Reservations.GetReservationByIDForUpdate(reservationID)
+				if err != nil {
+					return err
+				}
+				// Reservasi yang sudah dikonfirmasi atau kedaluwarsa di antara dua query dilewati
+				if reservation.Status != "held" {
+					return nil
+				}
+				return releaseReservation(repos, reservation, "cancelled")
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.txManager.WithinTx(func(repos repository.Repositories) error {
+		return repos.Waitlist.ExpireWaitingEntriesByEventID(eventID)
+	})
+}
+
+// cancellationUnfinished bernilai true selama job masih memiliki tiket yang harus dibatalkan atau dicoba ulang
+func cancellationUnfinished(cancellation entity.EventCancellation) bool {
+	return cancellation.Status == "running" || cancellation.Status == "completed_with_errors"
+}
+
+// cancelTicket membatalkan satu tiket dengan refund penuh dan mengantrekan notifikasi untuk pembelinya.
+// Refund yang dikembalikan masih pending dan harus dikirim ke provider oleh pemanggil.
+func (s *eventCancellationService) cancelTicket(cancellationID uint, ticketID uint) (entity.Refund, error) {
+	var refund entity.Refund
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Kunci job lebih dulu agar worker lain tidak memproses tiket yang sama bersamaan
+		cancellation, err := repos.Cancellations.GetCancellationByIDForUpdate(cancellationID)
+		if err != nil {
+			return err
+		}
+		if !cancellationUnfinished(cancellation) {
+			return nil
+		}
+
+		ticket, err := repos.Tickets.GetTicketByIDForUpdate(ticketID)
+		if err != nil {
+			return ErrTicketNotFound
+		}
+		if ticket.Status != "purchased" && ticket.Status != "pending_payment" {
+			return repos.Cancellations.AdvanceCursor(cancellation.ID, ticket.ID)
+		}
+
+		event, err := repos.Events.GetEventByIDForUpdate(ticket.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		items, err := repos.TicketItems.GetItemsByTicketIDForUpdate(ticket.ID)
+		if err != nil {
+			return err
+		}
+		seats := ticket.Quantity
+		if len(items) > 0 {
+			seats = 0
+		}
+		for _, item := range items {
+			if item.Status != "active" {
+				continue
+			}
+			seats++
+			if err := repos.TicketItems.UpdateItemStatus(item.ID, "cancelled"); err != nil {
+				return err
+			}
+		}
+
+		refunded := money.Zero(ticket.Price.Currency)
+		if ticket.Status == "pending_payment" {
+			// Tiket yang belum dibayar cukup melepas kursi yang ditahan
+			if err := repos.Events.AddReservedCount(event.ID, -ticket.Quantity); err != nil {
+				return errors.New("failed to update event capacity")
+			}
+			if ticket.TicketTypeID != nil {
+				if err := repos.TicketTypes.AddReservedCount(*ticket.TicketTypeID, -ticket.Quantity); err != nil {
+					return errors.New("failed to update ticket type quota")
+				}
+			}
+		} else {
+			refund, err = releaseAndRefund(repos, ticket, event, seats, seats, nil, "event cancelled: "+cancellation.Reason)
+			if err != nil {
+				return err
+			}
+			refunded = refund.Amount
+		}
+
+		if err := repos.Tickets.UpdateTicketStatus(ticket.ID, "cancelled"); err != nil {
+			return err
+		}
+		if err := repos.EventSeats.ReleaseSeatsByTicketID(ticket.ID); err != nil {
+			return err
+		}
+
+		if _, err := repos.Notifications.CreateNotification(entity.Notification{
+			UserID:  ticket.UserID,
+			Type:    "event_cancelled",
+			Subject: fmt.Sprintf("%s has been cancelled", event.Name),
+			Body:    fmt.Sprintf("Your booking %s for %s has been cancelled. Refund: %s.", ticket.Code, event.Name, refunded),
+			Status:  "pending",
+		}); err != nil {
+			return err
+		}
+
+		return repos.Cancellations.RecordTicketProcessed(cancellation.ID, ticket.ID, refunded)
+	})
+	if err != nil {
+		return entity.Refund{}, err
+	}
+	return refund, nil
+}
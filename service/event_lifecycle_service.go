@@ -0,0 +1,36 @@
+package service
+
+import (
+	"eventix/clock"
+	"eventix/repository"
+)
+
+type EventLifecycleService interface {
+	AdvanceEventStatuses() (int64, error)
+}
+
+type eventLifecycleService struct {
+	repo  repository.EventRepository
+	clock clock.Clock
+}
+
+func NewEventLifecycleService(repo repository.EventRepository, clk clock.Clock) EventLifecycleService {
+	return &eventLifecycleService{repo: repo, clock: clk}
+}
+
+// AdvanceEventStatuses memindahkan event ke ongoing saat StartDate dan ke completed saat EndDate.
+// Event draft dan cancelled tidak pernah diubah otomatis.
+func (s *eventLifecycleService) AdvanceEventStatuses() (int64, error) {
+	now := s.clock.Now()
+
+	// Selesaikan dulu event yang sudah berakhir agar event singkat tidak sempat ditandai ongoing
+	completed, err := s.repo.CompleteDueEvents(now)
+	if err != nil {
+		return 0, err
+	}
+	started, err := s.repo.StartDueEvents(now)
+	if err != nil {
+		return completed, err
+	}
+	return completed + started, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"eventix/clock"
+	"eventix/scheduler"
+	"testing"
+	"time"
+)
+
+// Event yang sudah berakhir diselesaikan lebih dulu agar event singkat tidak sempat ditandai ongoing
+func TestAdvanceEventStatusesCompletesBeforeStarting(t *testing.T) {
+	events := newFakeEventRepo()
+	events.started, events.completed = 2, 1
+
+	changed, err := NewEventLifecycleService(events, &clock.Fixed{At: time.Now()}).AdvanceEventStatuses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed != 3 {
+		t.Errorf("changed %d events, want 3", changed)
+	}
+	if len(events.calls) != 2 || events.calls[0] != "complete" || events.calls[1] != "start" {
+		t.Fatalf("repository calls %v, want [complete start]", events.calls)
+	}
+}
+
+// fakeLocker meniru lock leader di tabel scheduler_locks
+type fakeLocker struct {
+	owner     string
+	expiresAt time.Time
+}
+
+func (l *fakeLocker) AcquireLock(name string, owner string, ttl time.Duration, now time.Time) (bool, error) {
+	if l.owner != "" && l.owner != owner && !l.expiresAt.Before(now) {
+		return false, nil
+	}
+	l.owner, l.expiresAt = owner, now.Add(ttl)
+	return true, nil
+}
+
+// Dengan beberapa replika hanya pemegang lock yang memindahkan status event;
+// replika lain mengambil alih setelah lock leader kedaluwarsa
+func TestAdvanceEventStatusesRunsOnLeaderOnly(t *testing.T) {
+	clk := &clock.Fixed{At: time.Date(2026, 11, 1, 19, 0, 0, 0, time.UTC)}
+	locker := &fakeLocker{}
+	replicas := map[string]*fakeEventRepo{"a": newFakeEventRepo(), "b": newFakeEventRepo()}
+	jobs := map[string]func() error{}
+	for owner, events := range replicas {
+		svc := NewEventLifecycleService(events, clk)
+		jobs[owner] = scheduler.WithLeaderLock(locker, clk, "event-lifecycle", owner, 3*time.Minute, func() error {
+			_, err := svc.AdvanceEventStatuses()
+			return err
+		})
+	}
+
+	for _, owner := range []string{"a", "b", "a", "b"} {
+		if err := jobs[owner](); err != nil {
+			t.Fatal(err)
+		}
+		clk.Advance(time.Minute)
+	}
+	if len(replicas["a"].calls) != 4 || len(replicas["b"].calls) != 0 {
+		t.Fatalf("leader made %d calls and follower %d, want 4 and 0", len(replicas["a"].calls), len(replicas["b"].calls))
+	}
+
+	// Leader berhenti; setelah ttl habis replika lain menjadi leader
+	clk.Advance(3 * time.Minute)
+	if err := jobs["b"](); err != nil {
+		t.Fatal(err)
+	}
+	if len(replicas["b"].calls) != 2 {
+		t.Fatalf("follower made %d calls after the lock expired, want 2", len(replicas["b"].calls))
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/recurrence"
+	"eventix/repository"
+	"strings"
+	"time"
+)
+
+type EventSeriesService interface {
+	GetAllSeries() ([]entity.EventSeries, error)
+	GetSeriesByID(id uint) (entity.EventSeries, error)
+	CreateSeries(series entity.EventSeries) (entity.EventSeries, error)
+	UpdateSeries(series entity.EventSeries) (entity.EventSeries, error)
+	AddException(seriesID uint, occurrenceStart time.Time, reason string) (entity.EventSeriesException, error)
+	RemoveException(seriesID uint, exceptionID uint) error
+	MaterializeSeries(id uint) (int, error)
+	MaterializeAllSeries() (int, error)
+}
+
+type eventSeriesService struct {
+	txManager repository.TxManager
+	repo      repository.EventSeriesRepository
+	eventRepo repository.EventRepository
+	venueRepo repository.VenueRepository
+	horizon   time.Duration
+}
+
+func NewEventSeriesService(txManager repository.TxManager, repo repository.EventSeriesRepository, eventRepo repository.EventRepository, venueRepo repository.VenueRepository, horizon time.Duration) EventSeriesService {
+	return &eventSeriesService{
+		txManager: txManager,
+		repo:      repo,
+		eventRepo: eventRepo,
+		venueRepo: venueRepo,
+		horizon:   horizon,
+	}
+}
+
+func (s *eventSeriesService) GetAllSeries() ([]entity.EventSeries, error) {
+	return s.repo.GetAllSeries()
+}
+
+// GetSeriesByID mengambil seri beserta kemunculan dan pengecualiannya
+func (s *eventSeriesService) GetSeriesByID(id uint) (entity.EventSeries, error) {
+	series, err := s.repo.GetSeriesWithOccurrences(id)
+	if err != nil {
+		return entity.EventSeries{}, errors.New("event series not found")
+	}
+	return series, nil
+}
+
+// CreateSeries menyimpan seri lalu langsung membuat kemunculan sampai batas horizon
+func (s *eventSeriesService) CreateSeries(series entity.EventSeries) (entity.EventSeries, error) {
+	series.Name = strings.TrimSpace(series.Name)
+	if series.Name == "" {
+		return entity.EventSeries{}, errors.New("series name is required")
+	}
+
+	// Waktu kemunculan dibandingkan per detik, sesuai presisi RRULE
+	series.StartsAt = series.StartsAt.Truncate(time.Second)
+	if !series.StartsAt.After(time.Now()) {
+		return entity.EventSeries{}, errors.New("series must start in the future")
+	}
+	if _, err := recurrence.Parse(series.RRule, series.StartsAt.Location()); err != nil {
+		return entity.EventSeries{}, err
+	}
+	if series.DurationMinutes <= 0 {
+		return entity.EventSeries{}, errors.New("duration minutes must be greater than zero")
+	}
+
+	if series.Status == "" {
+		series.Status = "active"
+	}
+	if series.Status != "draft" && series.Status != "active" {
+		return entity.EventSeries{}, errors.New("series status must be either draft or active")
+	}
+	if series.TransferPolicy == "" {
+		series.TransferPolicy = "allowed"
+	}
+	if err := s.validateTemplate(&series); err != nil {
+		return entity.EventSeries{}, err
+	}
+
+	// Nama seri dipakai semua kemunculannya sehingga harus unik terhadap seri dan event lain
+	isUnique, err := s.eventRepo.IsEventNameUnique(series.Name, 0, nil)
+	if err != nil {
+		return entity.EventSeries{}, err
+	}
+	if !isUnique {
+		return entity.EventSeries{}, errors.New("event name must be unique")
+	}
+
+	series.ID = 0
+	series.MaterializedUntil = nil
+	series.Exceptions = nil
+	series.Occurrences = nil
+	created, err := s.repo.CreateSeries(series)
+	if err != nil {
+		return entity.EventSeries{}, err
+	}
+
+	if _, err := s.MaterializeSeries(created.ID); err != nil {
+		return entity.EventSeries{}, err
+	}
+	return s.GetSeriesByID(created.ID)
+}
+
+// UpdateSeries mengubah template seri dan menerapkannya ke kemunculan mendatang yang tidak diubah sendiri
+func (s *eventSeriesService) UpdateSeries(series entity.EventSeries) (entity.EventSeries, error) {
+	if series.TransferPolicy == "" {
+		series.TransferPolicy = "allowed"
+	}
+	if err := s.validateTemplate(&series); err != nil {
+		return entity.EventSeries{}, err
+	}
+
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Urutan lock: seri lalu kemunculannya, sama dengan materialisasi
+		existing, err := repos.Series.GetSeriesByIDForUpdate(series.ID)
+		if err != nil {
+			return errors.New("event series not found")
+		}
+		if series.Status == "" {
+			series.Status = existing.Status
+		}
+		if series.Status != "draft" && series.Status != "active" {
+			return errors.New("series status must be either draft or active")
+		}
+		// Kemunculan, kategori dan tiketnya sudah memakai mata uang seri
+		if series.Price.Currency != existing.Price.Currency {
+			return errors.New("series currency cannot be changed")
+		}
+
+		occurrences, err := repos.Series.GetUpcomingOccurrencesForUpdate(series.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		ids := make([]uint, 0, len(occurrences))
+		for _, occurrence := range occurrences {
+			if series.Capacity < occurrence.SoldCount+occurrence.ReservedCount {
+				return errors.New("capacity cannot be lower than the number of seats already sold or reserved for an occurrence")
+			}
+			// Kemunculan yang sudah punya pembeli tidak bisa dikembalikan menjadi draft
+			if series.Status == "draft" && occurrence.SoldCount+occurrence.ReservedCount > 0 {
+				return errors.New("series cannot be set to draft because an occurrence already has tickets")
+			}
+			ids = append(ids, occurrence.ID)
+		}
+
+		if err := repos.Series.UpdateSeriesTemplate(series); err != nil {
+			return err
+		}
+		return repos.Series.ApplyTemplateToOccurrences(ids, series)
+	})
+	if err != nil {
+		return entity.EventSeries{}, err
+	}
+
+	return s.GetSeriesByID(series.ID)
+}
+
+// AddException mengecualikan satu kemunculan; kemunculan yang sudah dibuat ikut dihapus selama belum ada tiket terjual
+func (s *eventSeriesService) AddException(seriesID uint, occurrenceStart time.Time, reason string) (entity.EventSeriesException, error) {
+	var created entity.EventSeriesException
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		series, err := repos.Series.GetSeriesByIDForUpdate(seriesID)
+		if err != nil {
+			return errors.New("event series not found")
+		}
+
+		start, err := matchOccurrence(series, occurrenceStart)
+		if err != nil {
+			return err
+		}
+		excluded, err := repos.Series.GetExceptionStarts(series.ID)
+		if err != nil {
+			return err
+		}
+		for _, excludedStart := range excluded {
+			if excludedStart.Unix() == start.Unix() {
+				return errors.New("occurrence is already excluded")
+			}
+		}
+
+		occurrence, err := repos.Series.GetOccurrenceByStart(series.ID, start)
+		if err == nil && !occurrence.DeletedAt.Valid {
+			if occurrence.StartDate.Before(time.Now()) {
+				return errors.New("occurrence cannot be excluded because it has already started")
+			}
+			isSold, err := repos.Tickets.IsTicketSold(occurrence.ID)
+			if err != nil {
+				return err
+			}
+			if isSold {
+				return errors.New("occurrence has tickets; cancel it through the event cancel endpoint instead")
+			}
+			if err := repos.Events.DeleteEvent(occurrence.ID); err != nil {
+				return err
+			}
+		}
+
+		created, err = repos.Series.CreateException(entity.EventSeriesException{
+			SeriesID:        series.ID,
+			OccurrenceStart: start,
+			Reason:          reason,
+		})
+		return err
+	})
+	return created, err
+}
+
+// RemoveException membatalkan pengecualian; kemunculan yang pernah dihapus dipulihkan jika belum lewat
+func (s *eventSeriesService) RemoveException(seriesID uint, exceptionID uint) error {
+	return s.txManager.WithinTx(func(repos repository.Repositories) error {
+		if _, err := repos.Series.GetSeriesByIDForUpdate(seriesID); err != nil {
+			return errors.New("event series not found")
+		}
+
+		exception, err := repos.Series.GetExceptionByID(seriesID, exceptionID)
+		if err != nil {
+			return errors.New("exception not found")
+		}
+		if !exception.OccurrenceStart.After(time.Now()) {
+			return errors.New("exception cannot be removed because the occurrence has already passed")
+		}
+		if err := repos.Series.DeleteException(exception.ID); err != nil {
+			return err
+		}
+
+		// Kemunculan yang belum pernah dibuat akan dibuat oleh materialisasi berikutnya
+		occurrence, err := repos.Series.GetOccurrenceByStart(seriesID, exception.OccurrenceStart)
+		if err != nil || !occurrence.DeletedAt.Valid {
+			return nil
+		}
+		return repos.Events.RestoreEvent(occurrence.ID)
+	})
+}
+
+// MaterializeSeries membuat event untuk kemunculan yang belum ada sampai batas horizon
+func (s *eventSeriesService) MaterializeSeries(id uint) (int, error) {
+	created := 0
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		series, err := repos.Series.GetSeriesByIDForUpdate(id)
+		if err != nil {
+			return errors.New("event series not found")
+		}
+		rule, err := recurrence.Parse(series.RRule, series.StartsAt.Location())
+		if err != nil {
+			return err
+		}
+
+		// Kemunculan yang sudah dibuat (termasuk yang dihapus) dan yang dikecualikan tidak dibuat ulang
+		skip := map[int64]bool{}
+		existing, err := repos.Series.GetOccurrenceStarts(series.ID)
+		if err != nil {
+			return err
+		}
+		exceptions, err := repos.Series.GetExceptionStarts(series.ID)
+		if err != nil {
+			return err
+		}
+		for _, start := range append(existing, exceptions...) {
+			skip[start.Unix()] = true
+		}
+
+		now := time.Now()
+		until := now.Add(s.horizon)
+		for _, start := range rule.Expand(series.StartsAt, until) {
+			if skip[start.Unix()] || !start.After(now) {
+				continue
+			}
+			if _, err := repos.Events.CreateEvent(series.NewOccurrence(start)); err != nil {
+				return err
+			}
+			created++
+		}
+		return repos.Series.SetMaterializedUntil(series.ID, until)
+	})
+	return created, err
+}
+
+// MaterializeAllSeries dijalankan scheduler untuk memajukan horizon seri yang tidak berbatas
+func (s *eventSeriesService) MaterializeAllSeries() (int, error) {
+	ids, err := s.repo.GetSeriesIDsToMaterialize(time.Now().Add(s.horizon))
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, id := range ids {
+		created, err := s.MaterializeSeries(id)
+		if err != nil {
+			return total, err
+		}
+		total += created
+	}
+	return total, nil
+}
+
+// validateTemplate memakai validasi event biasa terhadap kemunculan contoh
+func (s *eventSeriesService) validateTemplate(series *entity.EventSeries) error {
+	if series.Capacity < 0 {
+		return errors.New("capacity must be greater than or equal to zero")
+	}
+	if err := validatePrice(&series.Price); err != nil {
+		return err
+	}
+	if series.SalesOpenDaysBefore < 0 {
+		return errors.New("sales open days before must be greater than or equal to zero")
+	}
+	if series.VenueID != nil {
+		if _, err := s.venueRepo.GetVenueByID(*series.VenueID); err != nil {
+			return errors.New("venue not found")
+		}
+	}
+
+	occurrence := series.NewOccurrence(series.StartsAt)
+	if err := validateRefundPolicy(occurrence); err != nil {
+		return err
+	}
+	if err := validateTransferPolicy(occurrence); err != nil {
+		return err
+	}
+	return validatePurchaseLimits(occurrence)
+}
+
+// matchOccurrence memastikan waktu yang diberikan adalah salah satu kemunculan menurut aturan seri
+func matchOccurrence(series entity.EventSeries, occurrenceStart time.Time) (time.Time, error) {
+	rule, err := recurrence.Parse(series.RRule, series.StartsAt.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, start := range rule.Expand(series.StartsAt, occurrenceStart) {
+		if start.Unix() == occurrenceStart.Unix() {
+			return start, nil
+		}
+	}
+	return time.Time{}, errors.New("the given time is not an occurrence of this series")
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"eventix/entity"
+	"eventix/money"
 	"eventix/repository"
 	"time"
 )
@@ -17,25 +18,67 @@ type EventService interface {
     SearchAndFilterEvents(filters map[string]interface{}, page int, size int) (map[string]interface{}, error) // Tambahkan metode ini
 }
 
+// Status event: draft belum dipublikasikan, active dijual, ongoing dan completed diatur scheduler, cancelled dibatalkan
+var validEventStatuses = map[string]bool{
+	"draft":     true,
+	"active":    true,
+	"ongoing":   true,
+	"completed": true,
+	"cancelled": true,
+}
+
 type eventService struct {
-	repo      repository.EventRepository
-	ticketRepo repository.TicketRepository
+	txManager      repository.TxManager
+	repo           repository.EventRepository
+	ticketRepo     repository.TicketRepository
+	ticketTypeRepo repository.TicketTypeRepository
+	ruleRepo       repository.PricingRuleRepository
+	venueRepo      repository.VenueRepository
 }
 
-func NewEventService(repo repository.EventRepository, ticketRepo repository.TicketRepository) EventService {
+func NewEventService(txManager repository.TxManager, repo repository.EventRepository, ticketRepo repository.TicketRepository, ticketTypeRepo repository.TicketTypeRepository, ruleRepo repository.PricingRuleRepository, venueRepo repository.VenueRepository) EventService {
 	return &eventService{
-		repo:      repo,
-		ticketRepo: ticketRepo,
+		txManager:      txManager,
+		repo:           repo,
+		ticketRepo:     ticketRepo,
+		ticketTypeRepo: ticketTypeRepo,
+		ruleRepo:       ruleRepo,
+		venueRepo:      venueRepo,
 	}
 }
 
 func (s *eventService) GetAllEvents(page int, size int, name string, status string) ([]entity.Event, int64, error) {
-    return s.repo.GetAllEvents(page, size, name, status)
+    // Event draft belum dipublikasikan
+    if status == "draft" {
+        return []entity.Event{}, 0, nil
+    }
+    events, total, err := s.repo.GetAllEvents(page, size, name, status)
+    if err != nil {
+        return nil, 0, err
+    }
+    return withSalesState(events), total, nil
 }
 
 
 func (s *eventService) GetEventByID(id uint) (entity.Event, error) {
-	return s.repo.GetEventByID(id)
+	event, err := s.repo.GetEventByID(id)
+	if err != nil {
+		return entity.Event{}, err
+	}
+	if event.Status == "draft" {
+		return entity.Event{}, errors.New("event not found")
+	}
+	event.SalesState = event.SalesStateAt(time.Now())
+	return event, nil
+}
+
+// withSalesState mengisi status penjualan agar klien bisa menampilkan "segera dijual" atau "penjualan ditutup"
+func withSalesState(events []entity.Event) []entity.Event {
+	now := time.Now()
+	for i := range events {
+		events[i].SalesState = events[i].SalesStateAt(now)
+	}
+	return events
 }
 
 func (s *eventService) CreateEvent(event entity.Event) (entity.Event, error) {
@@ -45,16 +88,54 @@ func (s *eventService) CreateEvent(event entity.Event) (entity.Event, error) {
 	}
 
 	// Validasi harga
-	if event.Price < 0 {
-		return entity.Event{}, errors.New("price must be greater than or equal to zero")
+	if err := validatePrice(&event.Price); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi kebijakan refund
+	if err := validateRefundPolicy(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi kebijakan transfer
+	if err := validateTransferPolicy(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi batas pembelian
+	if err := validatePurchaseLimits(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi jendela penjualan
+	if err := validateSalesWindow(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi venue
+	if err := s.validateVenue(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	if event.TransferPolicy == "" {
+		event.TransferPolicy = "allowed"
 	}
 
+	// Penghitung kursi selalu dimulai dari nol
+	event.SoldCount = 0
+	event.ReservedCount = 0
+
+	// Kemunculan seri hanya dibuat lewat materialisasi seri
+	event.SeriesID = nil
+	event.OccurrenceStart = nil
+	event.SeriesOverride = false
+
 	// Validasi status
 	if event.Status == "" {
 		event.Status = "active"
 	}
 
-	if event.Status != "active" && event.Status != "ongoing" && event.Status != "completed" {
+	if !validEventStatuses[event.Status] || event.Status == "cancelled" {
 		return entity.Event{}, errors.New("invalid status value")
 	}
 
@@ -79,56 +160,216 @@ func (s *eventService) UpdateEvent(event entity.Event) (entity.Event, error) {
 		return entity.Event{}, errors.New("event cannot be updated because it has already started")
 	}
 
+	// Event yang dibatalkan tidak bisa diubah lagi
+	if existingEvent.Status == "cancelled" {
+		return entity.Event{}, errors.New("event cannot be updated because it has been cancelled")
+	}
+
 	// Validasi kapasitas
 	if event.Capacity < 0 {
 		return entity.Event{}, errors.New("capacity must be greater than or equal to zero")
 	}
+	if event.Capacity > 0 && event.Capacity < existingEvent.SoldCount+existingEvent.ReservedCount {
+		return entity.Event{}, errors.New("capacity cannot be lower than the number of seats already sold or reserved")
+	}
 
-	// Validasi harga
-	if event.Price < 0 {
-		return entity.Event{}, errors.New("price must be greater than or equal to zero")
+	// Validasi harga; mata uang event dipakai oleh kategori, aturan harga dan tiket yang sudah ada
+	if err := validatePrice(&event.Price); err != nil {
+		return entity.Event{}, err
+	}
+	if event.Price.Currency != existingEvent.Price.Currency {
+		if err := s.validateCurrencyChange(existingEvent); err != nil {
+			return entity.Event{}, err
+		}
+	}
+
+	// Validasi kebijakan refund
+	if err := validateRefundPolicy(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi kebijakan transfer
+	if err := validateTransferPolicy(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi batas pembelian
+	if err := validatePurchaseLimits(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi jendela penjualan
+	if err := validateSalesWindow(event); err != nil {
+		return entity.Event{}, err
+	}
+
+	// Validasi venue; kursi yang sudah terjual terikat ke denah venue lama
+	if err := s.validateVenue(event); err != nil {
+		return entity.Event{}, err
+	}
+	if event.VenueID != nil && (existingEvent.VenueID == nil || *existingEvent.VenueID != *event.VenueID) &&
+		existingEvent.SoldCount+existingEvent.ReservedCount > 0 {
+		return entity.Event{}, errors.New("venue cannot be changed after tickets have been sold or reserved")
 	}
 
 	// Validasi status
 	if event.Status != "" {
-		if event.Status != "active" && event.Status != "ongoing" && event.Status != "completed" {
+		if !validEventStatuses[event.Status] {
 			return entity.Event{}, errors.New("invalid status value")
 		}
 	}
 
+	// Pembatalan harus lewat endpoint cancel agar tiket ikut dibatalkan dan direfund
+	if event.Status == "cancelled" {
+		return entity.Event{}, errors.New("use the cancel endpoint to cancel an event")
+	}
+
+	// Keanggotaan seri tidak bisa diubah; kemunculan yang diubah sendiri berhenti mengikuti template seri
+	event.SeriesID = nil
+	event.OccurrenceStart = nil
+	event.SeriesOverride = existingEvent.SeriesID != nil
+
 	// Proses update event
 	return s.repo.UpdateEvent(event)
 }
 
 func (s *eventService) DeleteEvent(eventID uint) error {
-	// Ambil event dari database
-	existingEvent, err := s.repo.GetEventByID(eventID)
-	if err != nil {
-		return errors.New("event not found")
+	return s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Ambil dan kunci data event agar tidak ada tiket baru terjual selama proses hapus
+		existingEvent, err := repos.Events.GetEventByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		// Validasi apakah event sudah berlangsung
+		if existingEvent.StartDate.Before(time.Now()) {
+			return errors.New("event cannot be deleted because it has already started")
+		}
+
+		// Validasi apakah tiket sudah terjual
+		isSold, err := repos.Tickets.IsTicketSold(eventID)
+		if err != nil {
+			return err
+		}
+		if isSold {
+			return errors.New("event cannot be deleted because tickets are already sold")
+		}
+
+		// Kemunculan seri yang dihapus dicatat sebagai pengecualian agar tidak dibuat ulang
+		if existingEvent.SeriesID != nil && existingEvent.OccurrenceStart != nil {
+			if _, err := repos.Series.CreateException(entity.EventSeriesException{
+				SeriesID:        *existingEvent.SeriesID,
+				OccurrenceStart: *existingEvent.OccurrenceStart,
+				Reason:          "occurrence deleted",
+			}); err != nil {
+				return err
+			}
+		}
+
+		// Proses penghapusan event
+		return repos.Events.DeleteEvent(eventID)
+	})
+}
+
+func (s *eventService) SearchEvents(name string, startDate string, capacity int) ([]entity.Event, error) {
+	return s.repo.SearchEvents(name, startDate, capacity)
+}
+
+// Validasi kebijakan refund
+func validateRefundPolicy(event entity.Event) error {
+	if event.RefundFullDaysBefore < 0 {
+		return errors.New("refund full days before must be greater than or equal to zero")
+	}
+	if event.RefundPartialPercent < 0 || event.RefundPartialPercent > 100 {
+		return errors.New("refund partial percent must be between 0 and 100")
 	}
+	return nil
+}
 
-	// Validasi apakah event sudah berlangsung
-	if existingEvent.StartDate.Before(time.Now()) {
-		return errors.New("event cannot be deleted because it has already started")
+// validatePrice menolak harga negatif; harga tanpa mata uang memakai mata uang default
+func validatePrice(price *money.Money) error {
+	if price.Currency == "" {
+		price.Currency = money.DefaultCurrency
 	}
+	price.Currency = money.NormalizeCurrency(price.Currency)
+	if !money.ValidCurrency(price.Currency) {
+		return money.ErrInvalidCurrency
+	}
+	if price.IsNegative() {
+		return errors.New("price must be greater than or equal to zero")
+	}
+	return nil
+}
 
-	// Validasi apakah tiket sudah terjual
-	err = s.CanDeleteEvent(eventID)
+// validateCurrencyChange hanya mengizinkan ganti mata uang selama event belum punya tiket, kategori atau aturan harga
+func (s *eventService) validateCurrencyChange(existingEvent entity.Event) error {
+	if existingEvent.SoldCount+existingEvent.ReservedCount > 0 {
+		return errors.New("currency cannot be changed after tickets have been sold or reserved")
+	}
+	ticketTypes, err := s.ticketTypeRepo.GetTicketTypesByEventID(existingEvent.ID)
 	if err != nil {
 		return err
 	}
+	rules, err := s.ruleRepo.GetRulesByEventID(existingEvent.ID)
+	if err != nil {
+		return err
+	}
+	if len(ticketTypes) > 0 || len(rules) > 0 {
+		return errors.New("currency cannot be changed while the event has ticket types or pricing rules")
+	}
+	return nil
+}
 
-	// Proses penghapusan event
-	return s.repo.DeleteEvent(eventID)
+// validateVenue memastikan venue yang dipilih ada
+func (s *eventService) validateVenue(event entity.Event) error {
+	if event.VenueID == nil {
+		return nil
+	}
+	if _, err := s.venueRepo.GetVenueByID(*event.VenueID); err != nil {
+		return errors.New("venue not found")
+	}
+	return nil
 }
 
-func (s *eventService) SearchEvents(name string, startDate string, capacity int) ([]entity.Event, error) {
-	return s.repo.SearchEvents(name, startDate, capacity)
+// Validasi kebijakan transfer tiket
+func validateTransferPolicy(event entity.Event) error {
+	if event.TransferPolicy != "" && event.TransferPolicy != "allowed" && event.TransferPolicy != "disallowed" {
+		return errors.New("transfer policy must be either allowed or disallowed")
+	}
+	if event.TransferCutoffHours < 0 {
+		return errors.New("transfer cutoff hours must be greater than or equal to zero")
+	}
+	return nil
+}
+
+// Validasi jendela penjualan tiket
+func validateSalesWindow(event entity.Event) error {
+	if event.SalesStartAt != nil && event.SalesEndAt != nil && !event.SalesStartAt.Before(*event.SalesEndAt) {
+		return errors.New("sales start must be before sales end")
+	}
+	if event.SalesEndAt != nil && !event.EndDate.IsZero() && event.SalesEndAt.After(event.EndDate) {
+		return errors.New("sales end cannot be after the event ends")
+	}
+	return nil
+}
+
+// Validasi batas pembelian per pesanan dan per user
+func validatePurchaseLimits(event entity.Event) error {
+	if event.MinPerOrder < 0 || event.MaxPerOrder < 0 || event.MaxPerUser < 0 {
+		return errors.New("purchase limits must be greater than or equal to zero")
+	}
+	if event.MaxPerOrder > 0 && event.MinPerOrder > event.MaxPerOrder {
+		return errors.New("min per order cannot be greater than max per order")
+	}
+	if event.MaxPerUser > 0 && event.MinPerOrder > event.MaxPerUser {
+		return errors.New("min per order cannot be greater than max per user")
+	}
+	return nil
 }
 
 // Validasi nama unik
 func (s *eventService) ValidateEventName(name string, excludeID uint) error {
-	isUnique, err := s.repo.IsEventNameUnique(name, excludeID)
+	isUnique, err := s.repo.IsEventNameUnique(name, excludeID, nil)
 	if err != nil {
 		return err
 	}
@@ -157,7 +398,7 @@ func (s *eventService) SearchAndFilterEvents(filters map[string]interface{}, pag
     }
 
     return map[string]interface{}{
-        "events":       events,
+        "events":       withSalesState(events),
         "total_items":  totalItems,
         "current_page": page,
         "page_size":    size,
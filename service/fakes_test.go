@@ -0,0 +1,375 @@
+package service
+
+import (
+	"eventix/entity"
+	"eventix/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Repository palsu berbasis memori untuk pengujian service tanpa database.
+// Setiap fake menanam interface repository-nya, sehingga method yang tidak dipakai pengujian
+// akan panic jika terpanggil alih-alih diam-diam mengembalikan nilai kosong.
+
+type fakeTx struct {
+	repos repository.Repositories
+}
+
+func (f *fakeTx) WithinTx(fn func(repos repository.Repositories) error) error {
+	return fn(f.repos)
+}
+
+type fakeEventRepo struct {
+	repository.EventRepository
+	events    map[uint]entity.Event
+	calls     []string
+	started   int64
+	completed int64
+}
+
+func newFakeEventRepo(events ...entity.Event) *fakeEventRepo {
+	repo := &fakeEventRepo{events: map[uint]entity.Event{}}
+	for _, event := range events {
+		repo.events[event.ID] = event
+	}
+	return repo
+}
+
+func (r *fakeEventRepo) GetEventByID(id uint) (entity.Event, error) {
+	event, ok := r.events[id]
+	if !ok {
+		return entity.Event{}, gorm.ErrRecordNotFound
+	}
+	return event, nil
+}
+
+func (r *fakeEventRepo) GetEventByIDForUpdate(id uint) (entity.Event, error) {
+	return r.GetEventByID(id)
+}
+
+func (r *fakeEventRepo) AddSoldCount(id uint, delta int) error {
+	event := r.events[id]
+	event.SoldCount += delta
+	r.events[id] = event
+	return nil
+}
+
+func (r *fakeEventRepo) AddReservedCount(id uint, delta int) error {
+	event := r.events[id]
+	event.ReservedCount += delta
+	r.events[id] = event
+	return nil
+}
+
+// StartDueEvents dan CompleteDueEvents hanya mencatat urutan panggilan; kondisi query-nya diuji
+// terhadap MySQL di paket repository
+func (r *fakeEventRepo) StartDueEvents(now time.Time) (int64, error) {
+	r.calls = append(r.calls, "start")
+	return r.started, nil
+}
+
+func (r *fakeEventRepo) CompleteDueEvents(now time.Time) (int64, error) {
+	r.calls = append(r.calls, "complete")
+	return r.completed, nil
+}
+
+type fakeTicketRepo struct {
+	repository.TicketRepository
+	tickets map[uint]entity.Ticket
+	nextID  uint
+}
+
+func newFakeTicketRepo(tickets ...entity.Ticket) *fakeTicketRepo {
+	repo := &fakeTicketRepo{tickets: map[uint]entity.Ticket{}}
+	for _, ticket := range tickets {
+		repo.tickets[ticket.ID] = ticket
+		if ticket.ID > repo.nextID {
+			repo.nextID = ticket.ID
+		}
+	}
+	return repo
+}
+
+func (r *fakeTicketRepo) GetTicketByID(id uint) (entity.Ticket, error) {
+	ticket, ok := r.tickets[id]
+	if !ok {
+		return entity.Ticket{}, gorm.ErrRecordNotFound
+	}
+	return ticket, nil
+}
+
+func (r *fakeTicketRepo) GetTicketByIDForUpdate(id uint) (entity.Ticket, error) {
+	return r.GetTicketByID(id)
+}
+
+func (r *fakeTicketRepo) CreateTicket(ticket entity.Ticket) (entity.Ticket, error) {
+	r.nextID++
+	ticket.ID = r.nextID
+	for i := range ticket.Items {
+		ticket.Items[i].ID = ticket.ID*100 + uint(i)
+		ticket.Items[i].TicketID = ticket.ID
+	}
+	r.tickets[ticket.ID] = ticket
+	return ticket, nil
+}
+
+func (r *fakeTicketRepo) CountUserActiveSeats(userID uint, eventID uint) (int64, error) {
+	var count int64
+	for _, ticket := range r.tickets {
+		if ticket.UserID != userID || ticket.EventID != eventID {
+			continue
+		}
+		if ticket.Status != "pending_payment" && ticket.Status != "purchased" {
+			continue
+		}
+		for _, item := range ticket.Items {
+			if item.Status == "active" {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeTicketRepo) GetTicketsByPaymentIntentID(intentID string) ([]entity.Ticket, error) {
+	var tickets []entity.Ticket
+	for _, ticket := range r.tickets {
+		if ticket.PaymentIntentID == intentID {
+			tickets = append(tickets, ticket)
+		}
+	}
+	return tickets, nil
+}
+
+func (r *fakeTicketRepo) UpdateTicketStatus(id uint, status string) error {
+	ticket := r.tickets[id]
+	ticket.Status = status
+	r.tickets[id] = ticket
+	return nil
+}
+
+// fakeTicketItemRepo membaca dan mengubah kursi yang tersimpan di dalam tiket fakeTicketRepo
+type fakeTicketItemRepo struct {
+	repository.TicketItemRepository
+	tickets *fakeTicketRepo
+}
+
+func (r *fakeTicketItemRepo) GetItemsByTicketIDForUpdate(ticketID uint) ([]entity.TicketItem, error) {
+	return r.tickets.tickets[ticketID].Items, nil
+}
+
+func (r *fakeTicketItemRepo) UpdateItemStatus(id uint, status string) error {
+	for _, ticket := range r.tickets.tickets {
+		for i := range ticket.Items {
+			if ticket.Items[i].ID == id {
+				ticket.Items[i].Status = status
+				return nil
+			}
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+type fakeRefundRepo struct {
+	repository.RefundRepository
+	refunds []entity.Refund
+}
+
+func (r *fakeRefundRepo) CreateRefund(refund entity.Refund) (entity.Refund, error) {
+	refund.ID = uint(len(r.refunds) + 1)
+	r.refunds = append(r.refunds, refund)
+	return refund, nil
+}
+
+type fakeOrderRepo struct {
+	repository.OrderRepository
+	orders map[uint]entity.Order
+}
+
+func (r *fakeOrderRepo) GetOrderByPaymentIntentID(intentID string) (entity.Order, error) {
+	for _, order := range r.orders {
+		if order.PaymentIntentID == intentID {
+			return order, nil
+		}
+	}
+	return entity.Order{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeOrderRepo) GetOrderByIDForUpdate(id uint) (entity.Order, error) {
+	order, ok := r.orders[id]
+	if !ok {
+		return entity.Order{}, gorm.ErrRecordNotFound
+	}
+	return order, nil
+}
+
+func (r *fakeOrderRepo) UpdatePendingOrderStatusByPaymentIntentID(intentID string, status string) error {
+	for id, order := range r.orders {
+		if order.PaymentIntentID == intentID && order.Status == "pending_payment" {
+			order.Status = status
+			r.orders[id] = order
+		}
+	}
+	return nil
+}
+
+type fakeEventSeatRepo struct {
+	repository.EventSeatRepository
+}
+
+func (r *fakeEventSeatRepo) ReleaseSeatsByTicketID(ticketID uint) error {
+	return nil
+}
+
+type fakeInvoiceRepo struct {
+	repository.InvoiceRepository
+	invoices map[uint]entity.Invoice // Berdasarkan OrderID
+}
+
+func (r *fakeInvoiceRepo) GetInvoiceByOrderID(orderID uint) (entity.Invoice, error) {
+	invoice, ok := r.invoices[orderID]
+	if !ok {
+		return entity.Invoice{}, gorm.ErrRecordNotFound
+	}
+	return invoice, nil
+}
+
+type fakeReservationRepo struct {
+	repository.ReservationRepository
+	held         int64
+	reservations map[uint]entity.Reservation
+}
+
+func (r *fakeReservationRepo) SumHeldQuantity(userID uint, eventID uint) (int64, error) {
+	return r.held, nil
+}
+
+func (r *fakeReservationRepo) GetReservationByIDForUpdate(id uint) (entity.Reservation, error) {
+	reservation, ok := r.reservations[id]
+	if !ok {
+		return entity.Reservation{}, gorm.ErrRecordNotFound
+	}
+	return reservation, nil
+}
+
+func (r *fakeReservationRepo) UpdateReservationStatus(id uint, status string, ticketID *uint) error {
+	reservation := r.reservations[id]
+	reservation.Status = status
+	reservation.TicketID = ticketID
+	r.reservations[id] = reservation
+	return nil
+}
+
+type fakeWaitlistRepo struct {
+	repository.WaitlistRepository
+	byReservation map[uint]string
+}
+
+func (r *fakeWaitlistRepo) UpdateEntryStatusByReservation(reservationID uint, status string) error {
+	r.byReservation[reservationID] = status
+	return nil
+}
+
+type fakeCartRepo struct {
+	repository.CartRepository
+	items  []entity.CartItem
+	nextID uint
+}
+
+func (r *fakeCartRepo) GetCartItems(userID uint) ([]entity.CartItem, error) {
+	var items []entity.CartItem
+	for _, item := range r.items {
+		if item.UserID == userID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeCartRepo) GetCartItemsForUpdate(userID uint) ([]entity.CartItem, error) {
+	return r.GetCartItems(userID)
+}
+
+func (r *fakeCartRepo) FindCartItem(userID uint, eventID uint, ticketTypeID *uint) (entity.CartItem, error) {
+	for _, item := range r.items {
+		if item.UserID == userID && item.EventID == eventID && sameID(item.TicketTypeID, ticketTypeID) {
+			return item, nil
+		}
+	}
+	return entity.CartItem{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeCartRepo) CreateCartItem(item entity.CartItem) (entity.CartItem, error) {
+	r.nextID++
+	item.ID = r.nextID
+	r.items = append(r.items, item)
+	return item, nil
+}
+
+func (r *fakeCartRepo) UpdateCartItemQuantity(id uint, quantity int) error {
+	for i := range r.items {
+		if r.items[i].ID == id {
+			r.items[i].Quantity = quantity
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+type fakeTicketTypeRepo struct {
+	repository.TicketTypeRepository
+	types map[uint]entity.TicketType
+}
+
+func newFakeTicketTypeRepo(types ...entity.TicketType) *fakeTicketTypeRepo {
+	repo := &fakeTicketTypeRepo{types: map[uint]entity.TicketType{}}
+	for _, ticketType := range types {
+		repo.types[ticketType.ID] = ticketType
+	}
+	return repo
+}
+
+func (r *fakeTicketTypeRepo) GetTicketTypesByEventID(eventID uint) ([]entity.TicketType, error) {
+	var types []entity.TicketType
+	for _, ticketType := range r.types {
+		if ticketType.EventID == eventID {
+			types = append(types, ticketType)
+		}
+	}
+	return types, nil
+}
+
+func (r *fakeTicketTypeRepo) GetTicketTypeByID(id uint) (entity.TicketType, error) {
+	ticketType, ok := r.types[id]
+	if !ok {
+		return entity.TicketType{}, gorm.ErrRecordNotFound
+	}
+	return ticketType, nil
+}
+
+func (r *fakeTicketTypeRepo) GetTicketTypeByIDForUpdate(id uint) (entity.TicketType, error) {
+	return r.GetTicketTypeByID(id)
+}
+
+func (r *fakeTicketTypeRepo) AddReservedCount(id uint, delta int) error {
+	ticketType := r.types[id]
+	ticketType.ReservedCount += delta
+	r.types[id] = ticketType
+	return nil
+}
+
+type fakePricingRuleRepo struct {
+	repository.PricingRuleRepository
+}
+
+func (r *fakePricingRuleRepo) GetRulesByEventID(eventID uint) ([]entity.PricingRule, error) {
+	return nil, nil
+}
+
+func sameID(a *uint, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
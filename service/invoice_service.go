@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/receipt"
+	"eventix/repository"
+	"eventix/ticketcode"
+	"fmt"
+	"time"
+)
+
+type InvoiceService interface {
+	GetTicketReceipt(ticketID uint, actorID uint, actorRole string) ([]byte, error)
+}
+
+type invoiceService struct {
+	repo           repository.InvoiceRepository
+	ticketRepo     repository.TicketRepository
+	eventRepo      repository.EventRepository
+	ticketTypeRepo repository.TicketTypeRepository
+	venueRepo      repository.VenueRepository
+	signer         *ticketcode.Signer
+}
+
+func NewInvoiceService(repo repository.InvoiceRepository, ticketRepo repository.TicketRepository, eventRepo repository.EventRepository, ticketTypeRepo repository.TicketTypeRepository, venueRepo repository.VenueRepository, signer *ticketcode.Signer) InvoiceService {
+	return &invoiceService{
+		repo:           repo,
+		ticketRepo:     ticketRepo,
+		eventRepo:      eventRepo,
+		ticketTypeRepo: ticketTypeRepo,
+		venueRepo:      venueRepo,
+		signer:         signer,
+	}
+}
+
+// GetTicketReceipt merender invoice order tiket beserta e-ticket untuk setiap kursi aktif menjadi PDF.
+// Invoice memuat data pembeli dan rincian harga sehingga hanya disertakan untuk pembeli order atau Admin;
+// pemegang tiket lain (mis. penerima transfer) hanya mendapat halaman e-ticket dari tiket yang sudah dibayar.
+func (s *invoiceService) GetTicketReceipt(ticketID uint, actorID uint, actorRole string) ([]byte, error) {
+	ticket, err := s.ticketRepo.GetTicketByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if err := authorizeTicketAccess(ticket, actorID, actorRole); err != nil {
+		return nil, err
+	}
+	if ticket.OrderID == nil {
+		return nil, ErrInvoiceNotFound
+	}
+
+	invoice, err := s.repo.GetInvoiceByOrderID(*ticket.OrderID)
+	if err != nil {
+		return nil, ErrInvoiceNotFound
+	}
+	withInvoice := actorRole == "Admin" || invoice.UserID == actorID
+	if !withInvoice && ticket.Status != "purchased" {
+		return nil, ErrTicketForbidden
+	}
+
+	var tickets []receipt.ETicket
+	if ticket.Status == "purchased" {
+		event, err := s.eventRepo.GetEventByID(ticket.EventID)
+		if err != nil {
+			return nil, errors.New("event not found")
+		}
+		eTicket := receipt.ETicket{EventName: event.Name, StartDate: event.StartDate}
+		if event.VenueID != nil {
+			if venue, err := s.venueRepo.GetVenueByID(*event.VenueID); err == nil {
+				eTicket.Venue = venue.Name
+			}
+		}
+		if ticket.TicketTypeID != nil {
+			if ticketType, err := s.ticketTypeRepo.GetTicketTypeByID(*ticket.TicketTypeID); err == nil {
+				eTicket.TicketType = ticketType.Name
+			}
+		}
+
+		// Satu halaman e-ticket per kursi aktif, masing-masing dengan QR kursinya sendiri
+		for _, item := range ticket.Items {
+			if item.Status != "active" {
+				continue
+			}
+			eTicket.HolderName = item.HolderName
+			eTicket.Code = item.Code
+			eTicket.Payload = s.signer.Payload(ticket.EventID, item.Code)
+			tickets = append(tickets, eTicket)
+		}
+	}
+
+	if !withInvoice {
+		return receipt.RenderETickets(invoice, tickets)
+	}
+	return receipt.Render(invoice, tickets)
+}
+
+// issueInvoice menerbitkan invoice untuk order yang baru lunas, di dalam transaksi yang sama dengan perubahan status order.
+// Order yang sudah memiliki invoice (mis. webhook terkirim dua kali) tidak diterbitkan ulang.
+func issueInvoice(repos repository.Repositories, orderID uint, now time.Time) error {
+	if _, err := repos.Invoices.GetInvoiceByOrderID(orderID); err == nil {
+		return nil
+	}
+
+	order, err := repos.Orders.GetOrderByID(orderID)
+	if err != nil {
+		return ErrOrderNotFound
+	}
+	sequence, err := repos.Invoices.NextSequence(now.Year())
+	if err != nil {
+		return err
+	}
+
+	invoice := entity.Invoice{
+		Number:        entity.InvoiceNumber(now.Year(), sequence),
+		OrderID:       order.ID,
+		OrderCode:     order.Code,
+		UserID:        order.UserID,
+		Subtotal:      order.Subtotal,
+		Discount:      order.Discount,
+		ServiceFee:    order.ServiceFee,
+		TaxableAmount: order.Subtotal.Sub(order.Discount).Add(order.ServiceFee),
+		TaxPercent:    order.TaxPercent,
+		Tax:           order.Tax,
+		Total:         order.Total,
+		IssuedAt:      now,
+	}
+	if user, err := repos.Users.GetUserByID(order.UserID); err == nil {
+		invoice.BuyerName = user.Username
+	}
+	if order.PromoCodeID != nil {
+		if promo, err := repos.PromoCodes.GetPromoCodeByID(*order.PromoCodeID); err == nil {
+			invoice.PromoCode = promo.Code
+		}
+	}
+
+	for _, ticket := range order.Tickets {
+		description := fmt.Sprintf("Event #%d", ticket.EventID)
+		if event, err := repos.Events.GetEventByID(ticket.EventID); err == nil {
+			description = event.Name
+		}
+		if ticket.TicketTypeID != nil {
+			if ticketType, err := repos.TicketTypes.GetTicketTypeByID(*ticket.TicketTypeID); err == nil {
+				description += " - " + ticketType.Name
+			}
+		}
+
+		amount := ticket.Price.Add(ticket.Discount)
+		invoice.Lines = append(invoice.Lines, entity.InvoiceLine{
+			TicketID:    ticket.ID,
+			Description: description,
+			Quantity:    ticket.Quantity,
+			UnitPrice:   amount.Share(1, ticket.Quantity),
+			Discount:    money.New(ticket.Discount.Amount, ticket.Price.Currency),
+			Total:       ticket.Price,
+		})
+
+		// Email pembeli tidak disimpan di akun; pakai email pemegang kursi pertama yang diisi
+		for _, item := range ticket.Items {
+			if invoice.BuyerEmail == "" && item.HolderEmail != "" {
+				invoice.BuyerEmail = item.HolderEmail
+			}
+		}
+	}
+
+	_, err = repos.Invoices.CreateInvoice(invoice)
+	return err
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/ticketcode"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var pdfPageCount = regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`)
+
+// Pembeli mendapat invoice dan e-ticket; penerima transfer hanya mendapat e-ticket tanpa rincian harga
+func TestGetTicketReceiptForBuyerAndRecipient(t *testing.T) {
+	orderID := uint(5)
+	events := newFakeEventRepo(entity.Event{ID: 1, Name: "Concert", StartDate: time.Now().Add(24 * time.Hour)})
+	tickets := newFakeTicketRepo(
+		entity.Ticket{ID: 1, EventID: 1, UserID: 10, OrderID: &orderID, Quantity: 1, Status: "purchased",
+			Items: []entity.TicketItem{{ID: 101, TicketID: 1, Code: "A1", Status: "active"}}},
+		// Kursi yang dipindahkan ke user 20 lewat transfer tetap menunjuk order pembeli
+		entity.Ticket{ID: 2, EventID: 1, UserID: 20, OrderID: &orderID, Quantity: 1, Status: "purchased",
+			Items: []entity.TicketItem{{ID: 201, TicketID: 2, Code: "A2", Status: "active"}}},
+		entity.Ticket{ID: 3, EventID: 1, UserID: 20, OrderID: &orderID, Quantity: 1, Status: "cancelled"},
+	)
+	invoices := &fakeInvoiceRepo{invoices: map[uint]entity.Invoice{
+		orderID: {ID: 1, OrderID: orderID, UserID: 10, Number: "INV-2026-000001", Total: money.New(10000, "IDR")},
+	}}
+	svc := NewInvoiceService(invoices, tickets, events, nil, nil, ticketcode.NewSigner("test"))
+
+	tests := []struct {
+		name     string
+		ticketID uint
+		actorID  uint
+		role     string
+		pages    string
+		err      error
+	}{
+		{"buyer", 1, 10, "User", "2", nil},
+		{"admin", 2, 99, "Admin", "2", nil},
+		{"transfer recipient", 2, 20, "User", "1", nil},
+		{"recipient of unpaid ticket", 3, 20, "User", "", ErrTicketForbidden},
+		{"other user", 1, 30, "User", "", ErrTicketForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdf, err := svc.GetTicketReceipt(tt.ticketID, tt.actorID, tt.role)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			match := pdfPageCount.FindSubmatch(pdf)
+			if match == nil || string(match[1]) != tt.pages {
+				t.Fatalf("receipt has %s pages, want %s", match, tt.pages)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/repository"
+	"eventix/ticketcode"
+	"time"
+)
+
+// OrderPricing menentukan biaya layanan dan pajak yang ditambahkan ke setiap order
+type OrderPricing struct {
+	ServiceFeePerTicket float64 // Biaya layanan per kursi dalam satuan utama mata uang order
+	TaxPercent          float64 // Persentase pajak atas subtotal ditambah biaya layanan
+}
+
+// Totals menghitung biaya layanan, pajak dan total tagihan dari subtotal setelah diskon dalam mata uang subtotal
+func (p OrderPricing) Totals(subtotal money.Money, quantity int) (serviceFee money.Money, tax money.Money, total money.Money) {
+	serviceFee = money.FromFloat(p.ServiceFeePerTicket, subtotal.Currency).Mul(quantity)
+	tax = subtotal.Add(serviceFee).Percent(p.TaxPercent)
+	total = subtotal.Add(serviceFee).Add(tax)
+	return serviceFee, tax, total
+}
+
+type OrderService interface {
+	GetCart(userID uint) (entity.Cart, error)
+	AddCartItem(item entity.CartItem) (entity.CartItem, error)
+	RemoveCartItem(userID uint, id uint) error
+	Checkout(userID uint, promoCode string) (entity.Order, error)
+	GetOrdersByUserID(userID uint, page int, size int) ([]entity.Order, int64, error)
+	GetOrderForUser(id uint, actorID uint, actorRole string) (entity.Order, error)
+}
+
+type orderService struct {
+	txManager      repository.TxManager
+	repo           repository.OrderRepository
+	cartRepo       repository.CartRepository
+	eventRepo      repository.EventRepository
+	ticketTypeRepo repository.TicketTypeRepository
+	ruleRepo       repository.PricingRuleRepository
+	payments       PaymentService
+	pricing        OrderPricing
+}
+
+func NewOrderService(txManager repository.TxManager, repo repository.OrderRepository, cartRepo repository.CartRepository, eventRepo repository.EventRepository, ticketTypeRepo repository.TicketTypeRepository, ruleRepo repository.PricingRuleRepository, payments PaymentService, pricing OrderPricing) OrderService {
+	return &orderService{
+		txManager:      txManager,
+		repo:           repo,
+		cartRepo:       cartRepo,
+		eventRepo:      eventRepo,
+		ticketTypeRepo: ticketTypeRepo,
+		ruleRepo:       ruleRepo,
+		payments:       payments,
+		pricing:        pricing,
+	}
+}
+
+// GetCart mengambil isi keranjang dengan harga terkini dan perkiraan total order
+func (s *orderService) GetCart(userID uint) (entity.Cart, error) {
+	items, err := s.cartRepo.GetCartItems(userID)
+	if err != nil {
+		return entity.Cart{}, err
+	}
+
+	cart := entity.Cart{Items: items}
+	now := time.Now()
+	for i := range cart.Items {
+		s.quoteCartItem(&cart.Items[i], now)
+		cart.Quantity += cart.Items[i].Quantity
+		cart.Subtotal = cart.Subtotal.Add(cart.Items[i].Subtotal)
+	}
+	cart.ServiceFee, cart.Tax, cart.Total = s.pricing.Totals(cart.Subtotal, cart.Quantity)
+	return cart, nil
+}
+
+// AddCartItem menambahkan tiket ke keranjang; event dan kategori yang sama digabung dalam satu baris
+func (s *orderService) AddCartItem(item entity.CartItem) (entity.CartItem, error) {
+	if item.Quantity <= 0 {
+		return entity.CartItem{}, ErrInvalidQuantity
+	}
+
+	var saved entity.CartItem
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		event, err := repos.Events.GetEventByID(item.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+		if err := checkSalesWindow(event, time.Now()); err != nil {
+			return err
+		}
+
+		quantity := item.Quantity
+		existing, findErr := repos.Carts.FindCartItem(item.UserID, item.EventID, item.TicketTypeID)
+		if findErr == nil {
+			quantity += existing.Quantity
+		}
+
+		// Pengecekan awal; kapasitas, kuota dan batas per user dicek ulang saat checkout
+		if err := checkPurchaseLimits(repos, event, item.UserID, quantity); err != nil {
+			return err
+		}
+		if quantity > event.AvailableCapacity() {
+			return ErrCapacityExceeded
+		}
+		unitPrice, err := resolveUnitPrice(repos, event, item.TicketTypeID, quantity)
+		if err != nil {
+			return err
+		}
+		if err := checkCartCurrency(repos, item, unitPrice.Currency); err != nil {
+			return err
+		}
+
+		if findErr == nil {
+			existing.Quantity = quantity
+			saved = existing
+			return repos.Carts.UpdateCartItemQuantity(existing.ID, quantity)
+		}
+
+		saved, err = repos.Carts.CreateCartItem(entity.CartItem{
+			UserID:       item.UserID,
+			EventID:      item.EventID,
+			TicketTypeID: item.TicketTypeID,
+			Quantity:     quantity,
+		})
+		return err
+	})
+	if err != nil {
+		return entity.CartItem{}, err
+	}
+
+	s.quoteCartItem(&saved, time.Now())
+	return saved, nil
+}
+
+func (s *orderService) RemoveCartItem(userID uint, id uint) error {
+	if err := s.cartRepo.DeleteCartItem(userID, id); err != nil {
+		return errors.New("cart item not found")
+	}
+	return nil
+}
+
+// Checkout membuat semua tiket di keranjang dalam satu transaksi: semua berhasil atau tidak sama sekali
+func (s *orderService) Checkout(userID uint, promoCode string) (entity.Order, error) {
+	var order entity.Order
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Keranjang dikunci dan diurutkan per event agar urutan lock event sama antar checkout
+		items, err := repos.Carts.GetCartItemsForUpdate(userID)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return ErrCartEmpty
+		}
+
+		tickets := make([]entity.Ticket, 0, len(items))
+		eventQuantity := map[uint]int{}
+		for _, item := range items {
+			ticket, err := reserveTicket(repos, entity.Ticket{
+				EventID:      item.EventID,
+				TicketTypeID: item.TicketTypeID,
+				UserID:       userID,
+				Quantity:     item.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+			tickets = append(tickets, ticket)
+
+			// Batas per pesanan berlaku untuk gabungan semua baris dari event yang sama
+			eventQuantity[item.EventID] += item.Quantity
+			event, err := repos.Events.GetEventByIDForUpdate(item.EventID)
+			if err != nil {
+				return errors.New("event not found")
+			}
+			if event.MaxPerOrder > 0 && eventQuantity[item.EventID] > event.MaxPerOrder {
+				return ErrAboveMaxPerOrder
+			}
+		}
+
+		if tickets, err = applyPromoCode(repos, promoCode, userID, tickets, time.Now()); err != nil {
+			return err
+		}
+		if order, err = createOrder(repos, s.pricing, userID, tickets, s.payments.PaymentDeadline(time.Now())); err != nil {
+			return err
+		}
+		return repos.Carts.ClearCart(userID)
+	})
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	// Mulai pembayaran di luar transaksi agar lock tidak tertahan selama memanggil provider
+	return s.payments.StartOrderPayment(order)
+}
+
+func (s *orderService) GetOrdersByUserID(userID uint, page int, size int) ([]entity.Order, int64, error) {
+	return s.repo.GetOrdersByUserID(userID, page, size)
+}
+
+// GetOrderForUser mengambil order milik user; admin boleh melihat order siapa pun
+func (s *orderService) GetOrderForUser(id uint, actorID uint, actorRole string) (entity.Order, error) {
+	order, err := s.repo.GetOrderByID(id)
+	if err != nil {
+		return entity.Order{}, ErrOrderNotFound
+	}
+	if actorRole != "Admin" && order.UserID != actorID {
+		return entity.Order{}, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// quoteCartItem mengisi harga terkini dan ketersediaan baris keranjang tanpa mengunci data
+func (s *orderService) quoteCartItem(item *entity.CartItem, now time.Time) {
+	event, err := s.eventRepo.GetEventByID(item.EventID)
+	if err != nil {
+		return
+	}
+	item.EventName = event.Name
+	item.UnitPrice = event.Price
+	item.Available = checkSalesWindow(event, now) == nil && item.Quantity <= event.AvailableCapacity()
+
+	if item.TicketTypeID != nil {
+		ticketType, err := s.ticketTypeRepo.GetTicketTypeByID(*item.TicketTypeID)
+		if err != nil {
+			item.Available = false
+			return
+		}
+		item.UnitPrice = ticketType.Price
+		item.Available = item.Available && ticketType.IsOnSale(now) && item.Quantity <= ticketType.AvailableQuota()
+	}
+
+	// Harga keranjang mengikuti aturan harga dinamis yang berlaku saat ini
+	if quote, err := dynamicUnitPrice(s.ruleRepo, s.ticketTypeRepo, event, item.TicketTypeID, item.UnitPrice, now); err == nil {
+		item.UnitPrice = quote.UnitPrice
+	}
+	item.Subtotal = item.UnitPrice.Mul(item.Quantity)
+}
+
+// checkCartCurrency menolak baris baru yang mata uangnya berbeda dari isi keranjang,
+// karena satu order hanya dibayar dengan satu intent pembayaran
+func checkCartCurrency(repos repository.Repositories, item entity.CartItem, currency string) error {
+	items, err := repos.Carts.GetCartItems(item.UserID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range items {
+		if existing.EventID == item.EventID {
+			continue
+		}
+		event, err := repos.Events.GetEventByID(existing.EventID)
+		if err != nil {
+			continue
+		}
+		if event.Price.Currency != currency {
+			return ErrCurrencyMismatch
+		}
+	}
+	return nil
+}
+
+// createOrder membuat order pending_payment dari tiket yang baru dibuat dalam transaksi yang sama.
+// Kursi order dilepas jika belum dibayar sampai paymentDueAt.
+func createOrder(repos repository.Repositories, pricing OrderPricing, userID uint, tickets []entity.Ticket, paymentDueAt time.Time) (entity.Order, error) {
+	code, err := ticketcode.NewCode()
+	if err != nil {
+		return entity.Order{}, err
+	}
+
+	order := entity.Order{
+		UserID:       userID,
+		Code:         code,
+		Status:       "pending_payment",
+		PaymentDueAt: &paymentDueAt,
+	}
+	ticketIDs := make([]uint, 0, len(tickets))
+	for _, ticket := range tickets {
+		if order.Subtotal.Currency != "" && !ticket.Price.SameCurrency(order.Subtotal) {
+			return entity.Order{}, ErrCurrencyMismatch
+		}
+		order.Quantity += ticket.Quantity
+		order.Subtotal = order.Subtotal.Add(ticket.Price).Add(ticket.Discount)
+		order.Discount = order.Discount.Add(ticket.Discount)
+		if ticket.PromoCodeID != nil {
+			order.PromoCodeID = ticket.PromoCodeID
+		}
+		ticketIDs = append(ticketIDs, ticket.ID)
+	}
+	order.Discount.Currency = order.Subtotal.Currency
+	order.TaxPercent = pricing.TaxPercent
+	order.ServiceFee, order.Tax, order.Total = pricing.Totals(order.Subtotal.Sub(order.Discount), order.Quantity)
+
+	if order, err = repos.Orders.CreateOrder(order); err != nil {
+		return entity.Order{}, err
+	}
+	if err := repos.Tickets.AssignTicketsToOrder(ticketIDs, order.ID); err != nil {
+		return entity.Order{}, err
+	}
+
+	// Pemakaian kode promo dicatat per order untuk batas pemakaian dan laporan
+	if order.PromoCodeID != nil {
+		if _, err := repos.PromoCodes.CreateRedemption(entity.PromoRedemption{
+			PromoCodeID: *order.PromoCodeID,
+			UserID:      userID,
+			OrderID:     order.ID,
+			Discount:    order.Discount,
+		}); err != nil {
+			return entity.Order{}, err
+		}
+	}
+
+	order.Tickets = tickets
+	for i := range order.Tickets {
+		order.Tickets[i].OrderID = &order.ID
+	}
+	return order, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/repository"
+	"testing"
+	"time"
+)
+
+func limitTestEvent() entity.Event {
+	return entity.Event{
+		ID:          1,
+		Name:        "Concert",
+		StartDate:   time.Now().Add(30 * 24 * time.Hour),
+		EndDate:     time.Now().Add(31 * 24 * time.Hour),
+		Capacity:    100,
+		Price:       money.New(10000, "IDR"),
+		Status:      "active",
+		MinPerOrder: 2,
+		MaxPerOrder: 4,
+		MaxPerUser:  6,
+	}
+}
+
+func TestCheckPurchaseLimits(t *testing.T) {
+	event := limitTestEvent()
+
+	tests := []struct {
+		name     string
+		owned    int // Kursi aktif yang sudah dimiliki user
+		held     int64
+		quantity int
+		want     error
+	}{
+		{"zero quantity", 0, 0, 0, ErrInvalidQuantity},
+		{"below min per order", 0, 0, 1, ErrBelowMinPerOrder},
+		{"at min per order", 0, 0, 2, nil},
+		{"at max per order", 0, 0, 4, nil},
+		{"above max per order", 0, 0, 5, ErrAboveMaxPerOrder},
+		{"owned seats up to max per user", 2, 0, 4, nil},
+		{"owned seats above max per user", 3, 0, 4, ErrAboveMaxPerUser},
+		{"held reservations count toward max per user", 2, 2, 3, ErrAboveMaxPerUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets := newFakeTicketRepo()
+			if tt.owned > 0 {
+				items := make([]entity.TicketItem, tt.owned)
+				for i := range items {
+					items[i].Status = "active"
+				}
+				_, _ = tickets.CreateTicket(entity.Ticket{EventID: event.ID, UserID: 10, Quantity: tt.owned, Status: "purchased", Items: items})
+			}
+			repos := repository.Repositories{Tickets: tickets, Reservations: &fakeReservationRepo{held: tt.held}}
+
+			if err := checkPurchaseLimits(repos, event, 10, tt.quantity); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func newLimitTestOrderService(cart *fakeCartRepo, types *fakeTicketTypeRepo) (OrderService, *fakeTicketRepo) {
+	events := newFakeEventRepo(limitTestEvent())
+	tickets := newFakeTicketRepo()
+	rules := &fakePricingRuleRepo{}
+	tx := &fakeTx{repos: repository.Repositories{
+		Events:       events,
+		Tickets:      tickets,
+		Carts:        cart,
+		TicketTypes:  types,
+		PricingRules: rules,
+		Reservations: &fakeReservationRepo{},
+	}}
+	return NewOrderService(tx, nil, cart, events, types, rules, nil, OrderPricing{}), tickets
+}
+
+// Menambah event yang sama ke keranjang menggabungkan jumlahnya, dan batas per pesanan berlaku pada gabungan itu
+func TestAddCartItemChecksMergedQuantity(t *testing.T) {
+	cart := &fakeCartRepo{}
+	svc, _ := newLimitTestOrderService(cart, newFakeTicketTypeRepo())
+
+	if _, err := svc.AddCartItem(entity.CartItem{UserID: 10, EventID: 1, Quantity: 3}); err != nil {
+		t.Fatalf("first add: %v", err)
+	}
+	saved, err := svc.AddCartItem(entity.CartItem{UserID: 10, EventID: 1, Quantity: 1})
+	if err != nil {
+		t.Fatalf("merged add within limit: %v", err)
+	}
+	if saved.Quantity != 4 || len(cart.items) != 1 {
+		t.Fatalf("cart line quantity %d with %d lines, want one line of 4", saved.Quantity, len(cart.items))
+	}
+
+	if _, err := svc.AddCartItem(entity.CartItem{UserID: 10, EventID: 1, Quantity: 1}); !errors.Is(err, ErrAboveMaxPerOrder) {
+		t.Fatalf("merged add above limit: got %v, want %v", err, ErrAboveMaxPerOrder)
+	}
+	if cart.items[0].Quantity != 4 {
+		t.Fatalf("rejected add changed cart quantity to %d", cart.items[0].Quantity)
+	}
+}
+
+// Baris keranjang dengan kategori berbeda dari event yang sama dihitung bersama saat checkout
+func TestCheckoutChecksLimitsAcrossCartLines(t *testing.T) {
+	typeA, typeB := uint(1), uint(2)
+	newTypes := func() *fakeTicketTypeRepo {
+		return newFakeTicketTypeRepo(
+			entity.TicketType{ID: typeA, EventID: 1, Name: "Regular", Price: money.New(10000, "IDR"), Quota: 50},
+			entity.TicketType{ID: typeB, EventID: 1, Name: "VIP", Price: money.New(20000, "IDR"), Quota: 50},
+		)
+	}
+
+	t.Run("max per order", func(t *testing.T) {
+		cart := &fakeCartRepo{items: []entity.CartItem{
+			{ID: 1, UserID: 10, EventID: 1, TicketTypeID: &typeA, Quantity: 3},
+			{ID: 2, UserID: 10, EventID: 1, TicketTypeID: &typeB, Quantity: 2},
+		}}
+		svc, _ := newLimitTestOrderService(cart, newTypes())
+
+		if _, err := svc.Checkout(10, ""); !errors.Is(err, ErrAboveMaxPerOrder) {
+			t.Fatalf("got %v, want %v", err, ErrAboveMaxPerOrder)
+		}
+	})
+
+	t.Run("max per user", func(t *testing.T) {
+		cart := &fakeCartRepo{items: []entity.CartItem{
+			{ID: 1, UserID: 10, EventID: 1, TicketTypeID: &typeA, Quantity: 2},
+			{ID: 2, UserID: 10, EventID: 1, TicketTypeID: &typeB, Quantity: 2},
+		}}
+		svc, tickets := newLimitTestOrderService(cart, newTypes())
+		// User sudah memegang tiga kursi; baris kedua membuat total tujuh melebihi batas enam
+		_, _ = tickets.CreateTicket(entity.Ticket{
+			EventID:  1,
+			UserID:   10,
+			Quantity: 3,
+			Status:   "purchased",
+			Items:    []entity.TicketItem{{Status: "active"}, {Status: "active"}, {Status: "active"}},
+		})
+
+		if _, err := svc.Checkout(10, ""); !errors.Is(err, ErrAboveMaxPerUser) {
+			t.Fatalf("got %v, want %v", err, ErrAboveMaxPerUser)
+		}
+	})
+}
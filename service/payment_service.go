@@ -0,0 +1,385 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/payment"
+	"eventix/repository"
+	"fmt"
+	"time"
+)
+
+type PaymentService interface {
+	StartOrderPayment(order entity.Order) (entity.Order, error)
+	HandleWebhook(payload []byte, signature string) error
+	SendRefund(refund entity.Refund) (entity.Refund, error)
+	RetryRefunds() (int, error)
+	AuthorizeIntent(intentID string, actorID uint) error
+	PaymentDeadline(now time.Time) time.Time
+	ExpireOverdueOrders() (int, error)
+}
+
+type paymentService struct {
+	txManager  repository.TxManager
+	orderRepo  repository.OrderRepository
+	refundRepo repository.RefundRepository
+	provider   payment.Provider
+	ttl        time.Duration
+}
+
+// Refund yang gagal dikirim ke provider dicoba ulang sampai batas ini, setelah itu ditangani manual
+const maxRefundAttempts = 5
+
+// NewPaymentService menerima ttl, yaitu lama order boleh menunggu pembayaran sebelum kursinya dilepas
+func NewPaymentService(txManager repository.TxManager, orderRepo repository.OrderRepository, refundRepo repository.RefundRepository, provider payment.Provider, ttl time.Duration) PaymentService {
+	return &paymentService{
+		txManager:  txManager,
+		orderRepo:  orderRepo,
+		refundRepo: refundRepo,
+		provider:   provider,
+		ttl:        ttl,
+	}
+}
+
+// PaymentDeadline mengembalikan batas pembayaran untuk order yang dibuat pada now
+func (s *paymentService) PaymentDeadline(now time.Time) time.Time {
+	return now.Add(s.ttl)
+}
+
+// StartOrderPayment membuat satu intent pembayaran untuk seluruh tiket order berstatus pending_payment.
+// Order gratis langsung diselesaikan tanpa melewati provider.
+func (s *paymentService) StartOrderPayment(order entity.Order) (entity.Order, error) {
+	if !order.Total.IsPositive() {
+		err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+			for _, ticket := range order.Tickets {
+				if err := completeTicketPayment(repos, ticket); err != nil {
+					return err
+				}
+			}
+			if err := repos.Orders.UpdateOrderStatus(order.ID, "paid"); err != nil {
+				return err
+			}
+			return issueInvoice(repos, order.ID, time.Now())
+		})
+		if err != nil {
+			return entity.Order{}, err
+		}
+		order.Status = "paid"
+		for i := range order.Tickets {
+			order.Tickets[i].Status = "purchased"
+		}
+		return order, nil
+	}
+
+	intent, err := s.provider.CreateIntent(order.Total, fmt.Sprintf("order-%d", order.ID))
+	if err != nil {
+		// Lepaskan kursi yang ditahan agar tidak menggantung tanpa pembayaran
+		if failErr := s.abandonOrderPayment(order.ID, ""); failErr != nil {
+			return entity.Order{}, failErr
+		}
+		return entity.Order{}, errors.New("failed to start payment")
+	}
+
+	if err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		for _, ticket := range order.Tickets {
+			if err := repos.Tickets.UpdateTicketPaymentIntent(ticket.ID, intent.ID); err != nil {
+				return err
+			}
+		}
+		return repos.Orders.UpdateOrderPaymentIntent(order.ID, intent.ID)
+	}); err != nil {
+		// Tanpa intent tersimpan, webhook tidak bisa menemukan tiketnya; batalkan intent dan lepaskan kursinya.
+		// Jika pelepasan ini juga gagal, job payment-expiry melepasnya setelah batas pembayaran.
+		if failErr := s.abandonOrderPayment(order.ID, intent.ID); failErr != nil {
+			return entity.Order{}, failErr
+		}
+		return entity.Order{}, errors.New("failed to start payment")
+	}
+
+	order.PaymentIntentID = intent.ID
+	order.PaymentClientSecret = intent.ClientSecret
+	for i := range order.Tickets {
+		order.Tickets[i].PaymentIntentID = intent.ID
+		order.Tickets[i].PaymentClientSecret = intent.ClientSecret
+	}
+	return order, nil
+}
+
+// HandleWebhook memproses notifikasi provider dan memindahkan tiket ke purchased atau payment_failed beserta order-nya
+func (s *paymentService) HandleWebhook(payload []byte, signature string) error {
+	event, err := s.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case payment.EventPaymentSucceeded:
+		captured := false
+		err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+			tickets, total, err := lockPendingTickets(repos, event.IntentID)
+			if err != nil {
+				return err
+			}
+			if len(tickets) == 0 || len(tickets) < total {
+				// Tiket sudah dibatalkan atau kedaluwarsa; order tidak boleh menjadi lunas. Jika hanya sebagian
+				// yang dibatalkan (mis. event dibatalkan), total intent tidak lagi sesuai sehingga tiket sisanya
+				// ikut dilepas dan intent dibatalkan agar pembeli tidak membayar kursi yang tidak diterbitkan
+				for _, ticket := range tickets {
+					if err := failTicketPayment(repos, ticket); err != nil {
+						return err
+					}
+				}
+				return repos.Orders.UpdatePendingOrderStatusByPaymentIntentID(event.IntentID, "payment_failed")
+			}
+
+			// Capture dijalankan selama tiket terkunci agar tidak dibatalkan di tengah jalan.
+			// Capture aman diulang sehingga webhook yang dikirim ulang setelah transaksi gagal tetap benar.
+			if err := s.provider.Capture(event.IntentID); err != nil {
+				return err
+			}
+			captured = true
+
+			for _, ticket := range tickets {
+				if err := completeTicketPayment(repos, ticket); err != nil {
+					return err
+				}
+			}
+			if err := repos.Orders.UpdatePendingOrderStatusByPaymentIntentID(event.IntentID, "paid"); err != nil {
+				return err
+			}
+			// Invoice diterbitkan bersamaan dengan order menjadi lunas
+			order, err := repos.Orders.GetOrderByPaymentIntentID(event.IntentID)
+			if err != nil || order.Status != "paid" {
+				return nil
+			}
+			return issueInvoice(repos, order.ID, time.Now())
+		})
+		if err != nil {
+			return err
+		}
+		if !captured {
+			// Tidak ada yang dibeli dengan pembayaran ini; batalkan agar dana pembeli tidak ditarik
+			return s.provider.Cancel(event.IntentID)
+		}
+		return nil
+	case payment.EventPaymentFailed:
+		return s.txManager.WithinTx(func(repos repository.Repositories) error {
+			tickets, _, err := lockPendingTickets(repos, event.IntentID)
+			if err != nil {
+				return err
+			}
+			for _, ticket := range tickets {
+				if err := failTicketPayment(repos, ticket); err != nil {
+					return err
+				}
+			}
+			return repos.Orders.UpdatePendingOrderStatusByPaymentIntentID(event.IntentID, "payment_failed")
+		})
+	default:
+		// Event lain diabaikan agar provider tidak mengirim ulang
+		return nil
+	}
+}
+
+// SendRefund mengirim refund pending ke provider setelah pembatalan tiket di-commit, lalu mencatat hasilnya.
+// Kegagalan provider dicatat sebagai status failed untuk dicoba ulang oleh RetryRefunds, bukan dikembalikan sebagai error.
+func (s *paymentService) SendRefund(refund entity.Refund) (entity.Refund, error) {
+	if refund.Status != "pending" && refund.Status != "failed" {
+		return refund, nil
+	}
+
+	refund.Attempts++
+	providerRefundID, err := s.provider.Refund(refund.PaymentIntentID, refund.Amount, refund.IdempotencyKey())
+	if err != nil {
+		refund.Status = "failed"
+		refund.FailureReason = err.Error()
+	} else {
+		refund.Status = "succeeded"
+		refund.FailureReason = ""
+		refund.ProviderRefundID = providerRefundID
+	}
+
+	if err := s.refundRepo.UpdateRefundResult(refund); err != nil {
+		return entity.Refund{}, err
+	}
+	return refund, nil
+}
+
+// RetryRefunds mengirim ulang refund yang gagal atau tertahan pending (mis. server mati setelah commit)
+func (s *paymentService) RetryRefunds() (int, error) {
+	ids, err := s.refundRepo.GetRetryableRefundIDs(time.Now().Add(-5*time.Minute), maxRefundAttempts, 100)
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	for _, id := range ids {
+		refund, err := s.refundRepo.GetRefundByID(id)
+		if err != nil {
+			return succeeded, err
+		}
+		if refund, err = s.SendRefund(refund); err != nil {
+			return succeeded, err
+		}
+		if refund.Status == "succeeded" {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
+
+// ExpireOverdueOrders membatalkan intent order yang melewati batas pembayaran lalu melepas kursinya
+func (s *paymentService) ExpireOverdueOrders() (int, error) {
+	ids, err := s.orderRepo.GetOverdueOrderIDs(time.Now(), 100)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	var errs []error
+	for _, id := range ids {
+		skipped := false
+		err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+			// Order dikunci agar webhook yang datang bersamaan menunggu sampai order selesai dilepas
+			order, err := repos.Orders.GetOrderByIDForUpdate(id)
+			if err != nil {
+				return err
+			}
+			if order.Status != "pending_payment" || order.PaymentDueAt == nil || order.PaymentDueAt.After(time.Now()) {
+				skipped = true
+				return nil
+			}
+
+			// Intent dibatalkan lebih dulu agar pembeli tidak bisa membayar kursi yang sudah dilepas.
+			// Pembatalan intent aman diulang jika transaksi ini gagal dan order diproses lagi.
+			if order.PaymentIntentID != "" {
+				if err := s.provider.Cancel(order.PaymentIntentID); err != nil {
+					return err
+				}
+			}
+
+			return failOrderPayment(repos, order)
+		})
+		if err != nil {
+			// Order lain tetap diproses; intent yang sudah di-capture (dibayar tepat di batas waktu) diselesaikan oleh webhook
+			errs = append(errs, fmt.Errorf("order %d: %w", id, err))
+			continue
+		}
+		if !skipped {
+			expired++
+		}
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// abandonOrderPayment membatalkan intent (jika sudah dibuat) dan melepas kursi order yang pembayarannya gagal dimulai.
+// Kursi tetap dilepas walaupun intent gagal dibatalkan; pembayaran yang masuk kemudian dibatalkan oleh webhook.
+func (s *paymentService) abandonOrderPayment(orderID uint, intentID string) error {
+	var cancelErr error
+	if intentID != "" {
+		cancelErr = s.provider.Cancel(intentID)
+	}
+
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		order, err := repos.Orders.GetOrderByIDForUpdate(orderID)
+		if err != nil {
+			return err
+		}
+		if order.Status != "pending_payment" {
+			return nil
+		}
+		return failOrderPayment(repos, order)
+	})
+	return errors.Join(cancelErr, err)
+}
+
+// AuthorizeIntent memastikan intent pembayaran milik order actor; intent milik user lain dianggap tidak ada
+func (s *paymentService) AuthorizeIntent(intentID string, actorID uint) error {
+	order, err := s.orderRepo.GetOrderByPaymentIntentID(intentID)
+	if err != nil || order.UserID != actorID {
+		return ErrOrderNotFound
+	}
+	return nil
+}
+
+// lockPendingTickets mengunci order dan tiket milik intent lalu mengembalikan tiket yang masih pending_payment
+// beserta jumlah seluruh tiket intent. Urutan lock (order lalu tiket) sama dengan job payment-expiry;
+// webhook ganda tidak menemukan tiket pending lagi.
+func lockPendingTickets(repos repository.Repositories, intentID string) ([]entity.Ticket, int, error) {
+	if order, err := repos.Orders.GetOrderByPaymentIntentID(intentID); err == nil {
+		if _, err := repos.Orders.GetOrderByIDForUpdate(order.ID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	tickets, err := repos.Tickets.GetTicketsByPaymentIntentID(intentID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var pending []entity.Ticket
+	for _, ticket := range tickets {
+		ticket, err := repos.Tickets.GetTicketByIDForUpdate(ticket.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ticket.Status == "pending_payment" {
+			pending = append(pending, ticket)
+		}
+	}
+	return pending, len(tickets), nil
+}
+
+// completeTicketPayment memindahkan kursi tiket dari ditahan menjadi terjual
+func completeTicketPayment(repos repository.Repositories, ticket entity.Ticket) error {
+	if err := repos.Events.AddReservedCount(ticket.EventID, -ticket.Quantity); err != nil {
+		return errors.New("failed to update event capacity")
+	}
+	if err := repos.Events.AddSoldCount(ticket.EventID, ticket.Quantity); err != nil {
+		return errors.New("failed to update event capacity")
+	}
+	if ticket.TicketTypeID != nil {
+		if err := repos.TicketTypes.AddReservedCount(*ticket.TicketTypeID, -ticket.Quantity); err != nil {
+			return errors.New("failed to update ticket type quota")
+		}
+		if err := repos.TicketTypes.AddSoldCount(*ticket.TicketTypeID, ticket.Quantity); err != nil {
+			return errors.New("failed to update ticket type quota")
+		}
+	}
+	return repos.Tickets.UpdateTicketStatus(ticket.ID, "purchased")
+}
+
+// failOrderPayment melepas kursi semua tiket order yang masih menunggu pembayaran lalu menandai order payment_failed.
+// Dipanggil di dalam transaksi setelah order dikunci.
+func failOrderPayment(repos repository.Repositories, order entity.Order) error {
+	for _, ticket := range order.Tickets {
+		ticket, err := repos.Tickets.GetTicketByIDForUpdate(ticket.ID)
+		if err != nil {
+			return err
+		}
+		if ticket.Status != "pending_payment" {
+			continue
+		}
+		if err := failTicketPayment(repos, ticket); err != nil {
+			return err
+		}
+	}
+	return repos.Orders.UpdateOrderStatus(order.ID, "payment_failed")
+}
+
+// failTicketPayment mengembalikan kursi tiket yang ditahan ke ketersediaan event
+func failTicketPayment(repos repository.Repositories, ticket entity.Ticket) error {
+	if err := repos.Events.AddReservedCount(ticket.EventID, -ticket.Quantity); err != nil {
+		return errors.New("failed to update event capacity")
+	}
+	if ticket.TicketTypeID != nil {
+		if err := repos.TicketTypes.AddReservedCount(*ticket.TicketTypeID, -ticket.Quantity); err != nil {
+			return errors.New("failed to update ticket type quota")
+		}
+	}
+	if err := repos.EventSeats.ReleaseSeatsByTicketID(ticket.ID); err != nil {
+		return err
+	}
+	return repos.Tickets.UpdateTicketStatus(ticket.ID, "payment_failed")
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"eventix/entity"
+	"eventix/money"
+	"eventix/payment"
+	"eventix/repository"
+	"testing"
+	"time"
+)
+
+// recordingProvider mencatat intent yang di-capture dan dibatalkan oleh service
+type recordingProvider struct {
+	*payment.FakeProvider
+	captured  []string
+	cancelled []string
+}
+
+func (p *recordingProvider) Capture(intentID string) error {
+	p.captured = append(p.captured, intentID)
+	return p.FakeProvider.Capture(intentID)
+}
+
+func (p *recordingProvider) Cancel(intentID string) error {
+	p.cancelled = append(p.cancelled, intentID)
+	return p.FakeProvider.Cancel(intentID)
+}
+
+// Pembayaran yang masuk setelah sebagian tiket order dibatalkan tidak boleh menarik total penuh
+func TestHandleWebhookCancelsIntentWhenOrderHasCancelledTicket(t *testing.T) {
+	provider := &recordingProvider{FakeProvider: payment.NewFakeProvider("test")}
+	intent, err := provider.CreateIntent(money.New(30000, "IDR"), "order-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := newFakeEventRepo(
+		entity.Event{ID: 1, Status: "cancelled", StartDate: time.Now().Add(24 * time.Hour)},
+		entity.Event{ID: 2, Status: "active", StartDate: time.Now().Add(24 * time.Hour), ReservedCount: 2},
+	)
+	tickets := newFakeTicketRepo(
+		// Tiket event pertama sudah dibatalkan oleh job pembatalan event
+		entity.Ticket{ID: 1, EventID: 1, UserID: 10, Quantity: 1, Price: money.New(10000, "IDR"), Status: "cancelled", PaymentIntentID: intent.ID},
+		entity.Ticket{ID: 2, EventID: 2, UserID: 10, Quantity: 2, Price: money.New(20000, "IDR"), Status: "pending_payment", PaymentIntentID: intent.ID},
+	)
+	orders := &fakeOrderRepo{orders: map[uint]entity.Order{
+		1: {ID: 1, UserID: 10, Total: intent.Amount, Status: "pending_payment", PaymentIntentID: intent.ID},
+	}}
+	tx := &fakeTx{repos: repository.Repositories{Events: events, Tickets: tickets, Orders: orders, EventSeats: &fakeEventSeatRepo{}}}
+	svc := NewPaymentService(tx, orders, nil, provider, time.Hour)
+
+	payload, signature, err := provider.Simulate(intent.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.HandleWebhook(payload, signature); err != nil {
+		t.Fatalf("handle webhook: %v", err)
+	}
+
+	if len(provider.captured) != 0 {
+		t.Errorf("intent captured %v, want no capture", provider.captured)
+	}
+	if len(provider.cancelled) != 1 || provider.cancelled[0] != intent.ID {
+		t.Errorf("cancelled intents %v, want [%s]", provider.cancelled, intent.ID)
+	}
+	if got := tickets.tickets[2].Status; got != "payment_failed" {
+		t.Errorf("remaining ticket status %q, want payment_failed", got)
+	}
+	if got := events.events[2].ReservedCount; got != 0 {
+		t.Errorf("event still reserves %d seats, want 0", got)
+	}
+	if got := orders.orders[1].Status; got != "payment_failed" {
+		t.Errorf("order status %q, want payment_failed", got)
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/pricing"
+	"eventix/repository"
+	"strings"
+	"time"
+)
+
+type PricingRuleService interface {
+	GetRulesByEventID(eventID uint) ([]entity.PricingRule, error)
+	CreateRule(rule entity.PricingRule) (entity.PricingRule, error)
+	UpdateRule(rule entity.PricingRule) (entity.PricingRule, error)
+	DeleteRule(eventID uint, id uint) error
+	PreviewPrice(eventID uint, ticketTypeID *uint, quantity int) (entity.PriceQuote, error)
+}
+
+type pricingRuleService struct {
+	repo           repository.PricingRuleRepository
+	eventRepo      repository.EventRepository
+	ticketTypeRepo repository.TicketTypeRepository
+}
+
+func NewPricingRuleService(repo repository.PricingRuleRepository, eventRepo repository.EventRepository, ticketTypeRepo repository.TicketTypeRepository) PricingRuleService {
+	return &pricingRuleService{
+		repo:           repo,
+		eventRepo:      eventRepo,
+		ticketTypeRepo: ticketTypeRepo,
+	}
+}
+
+func (s *pricingRuleService) GetRulesByEventID(eventID uint) ([]entity.PricingRule, error) {
+	if _, err := s.eventRepo.GetEventByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+	return s.repo.GetRulesByEventID(eventID)
+}
+
+// CreateRule menyimpan aturan harga baru dalam keadaan aktif
+func (s *pricingRuleService) CreateRule(rule entity.PricingRule) (entity.PricingRule, error) {
+	if err := s.validateRule(&rule); err != nil {
+		return entity.PricingRule{}, err
+	}
+	rule.ID = 0
+	rule.IsActive = true
+	return s.repo.CreateRule(rule)
+}
+
+func (s *pricingRuleService) UpdateRule(rule entity.PricingRule) (entity.PricingRule, error) {
+	if _, err := s.repo.GetRuleByID(rule.EventID, rule.ID); err != nil {
+		return entity.PricingRule{}, errors.New("pricing rule not found")
+	}
+	if err := s.validateRule(&rule); err != nil {
+		return entity.PricingRule{}, err
+	}
+	return s.repo.UpdateRule(rule)
+}
+
+// DeleteRule menghapus aturan yang belum pernah dipakai; aturan yang sudah menentukan harga tiket cukup dinonaktifkan
+func (s *pricingRuleService) DeleteRule(eventID uint, id uint) error {
+	if _, err := s.repo.GetRuleByID(eventID, id); err != nil {
+		return errors.New("pricing rule not found")
+	}
+	isUsed, err := s.repo.IsRuleUsed(id)
+	if err != nil {
+		return err
+	}
+	if isUsed {
+		return errors.New("pricing rule has already priced tickets; deactivate it instead")
+	}
+	return s.repo.DeleteRule(id)
+}
+
+// PreviewPrice menghitung harga yang akan dibayar saat ini tanpa menahan kursi.
+// Kode promo, biaya layanan dan pajak tidak termasuk.
+func (s *pricingRuleService) PreviewPrice(eventID uint, ticketTypeID *uint, quantity int) (entity.PriceQuote, error) {
+	if quantity <= 0 {
+		return entity.PriceQuote{}, ErrInvalidQuantity
+	}
+
+	event, err := s.eventRepo.GetEventByID(eventID)
+	if err != nil || event.Status == "draft" {
+		return entity.PriceQuote{}, errors.New("event not found")
+	}
+
+	basePrice := event.Price
+	if ticketTypeID == nil {
+		ticketTypes, err := s.ticketTypeRepo.GetTicketTypesByEventID(event.ID)
+		if err != nil {
+			return entity.PriceQuote{}, err
+		}
+		if len(ticketTypes) > 0 {
+			return entity.PriceQuote{}, errors.New("ticket_type_id is required for this event")
+		}
+	} else {
+		ticketType, err := s.ticketTypeRepo.GetTicketTypeByID(*ticketTypeID)
+		if err != nil || ticketType.EventID != event.ID {
+			return entity.PriceQuote{}, errors.New("ticket type not found")
+		}
+		basePrice = ticketType.Price
+	}
+
+	result, err := dynamicUnitPrice(s.repo, s.ticketTypeRepo, event, ticketTypeID, basePrice, time.Now())
+	if err != nil {
+		return entity.PriceQuote{}, err
+	}
+
+	return entity.PriceQuote{
+		EventID:      event.ID,
+		TicketTypeID: ticketTypeID,
+		Quantity:     quantity,
+		BasePrice:    result.BasePrice,
+		UnitPrice:    result.UnitPrice,
+		Total:        result.UnitPrice.Mul(quantity),
+		Rule:         result.Rule,
+	}, nil
+}
+
+// validateRule memeriksa syarat sesuai jenis aturan, besar penyesuaian dan kategori yang dicakup
+func (s *pricingRuleService) validateRule(rule *entity.PricingRule) error {
+	event, err := s.eventRepo.GetEventByID(rule.EventID)
+	if err != nil {
+		return errors.New("event not found")
+	}
+	rule.Name = strings.TrimSpace(rule.Name)
+
+	switch rule.RuleType {
+	case "early_bird":
+		if rule.ValidUntil == nil {
+			return errors.New("valid_until is required for early_bird rules")
+		}
+	case "sold_threshold":
+		if rule.SoldThreshold <= 0 {
+			return errors.New("sold_threshold must be greater than zero")
+		}
+	case "last_minute":
+		if rule.HoursBeforeStart <= 0 {
+			return errors.New("hours_before_start must be greater than zero")
+		}
+	default:
+		return errors.New("rule type must be early_bird, sold_threshold or last_minute")
+	}
+
+	switch rule.AdjustmentType {
+	case "percentage":
+		if rule.Value < -100 {
+			return errors.New("percentage adjustment cannot be lower than -100")
+		}
+		rule.Amount = money.Money{}
+	case "amount", "price":
+		if rule.AdjustmentType == "price" && rule.Amount.IsNegative() {
+			return errors.New("price must be greater than or equal to zero")
+		}
+		if err := matchEventCurrency(&rule.Amount, event); err != nil {
+			return err
+		}
+		rule.Value = 0
+	default:
+		return errors.New("adjustment type must be percentage, amount or price")
+	}
+
+	if rule.TicketTypeID != nil {
+		ticketType, err := s.ticketTypeRepo.GetTicketTypeByID(*rule.TicketTypeID)
+		if err != nil || ticketType.EventID != rule.EventID {
+			return errors.New("ticket type not found")
+		}
+	}
+	return nil
+}
+
+// dynamicUnitPrice menerapkan aturan harga event pada harga dasar. Ambang terjual memakai kursi terjual
+// pada kategori yang dibeli, atau pada event jika tanpa kategori, sebelum pembelian ini.
+func dynamicUnitPrice(rules repository.PricingRuleRepository, ticketTypes repository.TicketTypeRepository, event entity.Event, ticketTypeID *uint, basePrice money.Money, now time.Time) (pricing.Result, error) {
+	eventRules, err := rules.GetRulesByEventID(event.ID)
+	if err != nil {
+		return pricing.Result{}, err
+	}
+	if len(eventRules) == 0 {
+		return pricing.Result{BasePrice: basePrice, UnitPrice: basePrice}, nil
+	}
+
+	state := pricing.State{
+		Now:          now,
+		StartDate:    event.StartDate,
+		TicketTypeID: ticketTypeID,
+		SoldCount:    event.SoldCount,
+	}
+	if ticketTypeID != nil {
+		ticketType, err := ticketTypes.GetTicketTypeByID(*ticketTypeID)
+		if err != nil {
+			return pricing.Result{}, errors.New("ticket type not found")
+		}
+		state.SoldCount = ticketType.SoldCount
+	}
+	return pricing.Evaluate(basePrice, eventRules, state), nil
+}
+
+// pricingRuleID mengembalikan ID aturan yang menghasilkan harga untuk dicatat di tiket
+func pricingRuleID(result pricing.Result) *uint {
+	if result.Rule == nil {
+		return nil
+	}
+	id := result.Rule.ID
+	return &id
+}
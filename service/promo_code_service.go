@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/repository"
+	"time"
+)
+
+type PromoCodeService interface {
+	GetAllPromoCodes() ([]entity.PromoCode, error)
+	GetPromoCodeByID(id uint) (entity.PromoCode, error)
+	CreatePromoCode(promo entity.PromoCode) (entity.PromoCode, error)
+	UpdatePromoCode(promo entity.PromoCode) (entity.PromoCode, error)
+	GetRedemptions(id uint, page int, size int) ([]entity.PromoRedemption, int64, error)
+}
+
+type promoCodeService struct {
+	repo           repository.PromoCodeRepository
+	eventRepo      repository.EventRepository
+	ticketTypeRepo repository.TicketTypeRepository
+}
+
+func NewPromoCodeService(repo repository.PromoCodeRepository, eventRepo repository.EventRepository, ticketTypeRepo repository.TicketTypeRepository) PromoCodeService {
+	return &promoCodeService{
+		repo:           repo,
+		eventRepo:      eventRepo,
+		ticketTypeRepo: ticketTypeRepo,
+	}
+}
+
+func (s *promoCodeService) GetAllPromoCodes() ([]entity.PromoCode, error) {
+	return s.repo.GetAllPromoCodes()
+}
+
+func (s *promoCodeService) GetPromoCodeByID(id uint) (entity.PromoCode, error) {
+	promo, err := s.repo.GetPromoCodeByID(id)
+	if err != nil {
+		return entity.PromoCode{}, ErrPromoCodeNotFound
+	}
+	return promo, nil
+}
+
+// CreatePromoCode menyimpan kode baru dalam keadaan aktif
+func (s *promoCodeService) CreatePromoCode(promo entity.PromoCode) (entity.PromoCode, error) {
+	promo.Code = entity.NormalizePromoCode(promo.Code)
+	if promo.Code == "" {
+		return entity.PromoCode{}, errors.New("promo code is required")
+	}
+	if err := s.validatePromoCode(&promo); err != nil {
+		return entity.PromoCode{}, err
+	}
+
+	isUnique, err := s.repo.IsPromoCodeUnique(promo.Code, 0)
+	if err != nil {
+		return entity.PromoCode{}, err
+	}
+	if !isUnique {
+		return entity.PromoCode{}, errors.New("promo code must be unique")
+	}
+
+	promo.ID = 0
+	promo.IsActive = true
+	return s.repo.CreatePromoCode(promo)
+}
+
+// UpdatePromoCode mengganti pengaturan kode; kodenya sendiri tidak bisa diubah karena sudah dipakai di order
+func (s *promoCodeService) UpdatePromoCode(promo entity.PromoCode) (entity.PromoCode, error) {
+	if _, err := s.repo.GetPromoCodeByID(promo.ID); err != nil {
+		return entity.PromoCode{}, ErrPromoCodeNotFound
+	}
+	if err := s.validatePromoCode(&promo); err != nil {
+		return entity.PromoCode{}, err
+	}
+	return s.repo.UpdatePromoCode(promo)
+}
+
+func (s *promoCodeService) GetRedemptions(id uint, page int, size int) ([]entity.PromoRedemption, int64, error) {
+	if _, err := s.repo.GetPromoCodeByID(id); err != nil {
+		return nil, 0, ErrPromoCodeNotFound
+	}
+	return s.repo.GetRedemptionsByPromoCodeID(id, page, size)
+}
+
+// validatePromoCode memeriksa jenis diskon, batas pemakaian, masa berlaku dan cakupan event/kategori
+func (s *promoCodeService) validatePromoCode(promo *entity.PromoCode) error {
+	switch promo.DiscountType {
+	case "percentage":
+		if promo.Value <= 0 || promo.Value > 100 {
+			return errors.New("percentage value must be greater than 0 and at most 100")
+		}
+		promo.DiscountAmount = money.Money{}
+	case "fixed":
+		if !promo.DiscountAmount.IsPositive() {
+			return errors.New("fixed discount amount must be greater than zero")
+		}
+		if err := validatePrice(&promo.DiscountAmount); err != nil {
+			return err
+		}
+		promo.Value = 0
+	case "buy_x_get_y":
+		if promo.BuyQuantity <= 0 || promo.GetQuantity <= 0 {
+			return errors.New("buy quantity and get quantity must be greater than zero")
+		}
+		promo.Value = 0
+		promo.DiscountAmount = money.Money{}
+	default:
+		return errors.New("discount type must be percentage, fixed or buy_x_get_y")
+	}
+	if promo.DiscountType != "buy_x_get_y" {
+		promo.BuyQuantity = 0
+		promo.GetQuantity = 0
+	}
+
+	if promo.MaxUses < 0 || promo.MaxUsesPerUser < 0 {
+		return errors.New("usage limits must be greater than or equal to zero")
+	}
+	if promo.StartsAt != nil && promo.EndsAt != nil && !promo.EndsAt.After(*promo.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+
+	// Kode untuk satu kategori otomatis dibatasi ke event kategori tersebut
+	if promo.TicketTypeID != nil {
+		ticketType, err := s.ticketTypeRepo.GetTicketTypeByID(*promo.TicketTypeID)
+		if err != nil {
+			return errors.New("ticket type not found")
+		}
+		if promo.EventID != nil && *promo.EventID != ticketType.EventID {
+			return errors.New("ticket type does not belong to the given event")
+		}
+		promo.EventID = &ticketType.EventID
+	}
+	if promo.EventID != nil {
+		if _, err := s.eventRepo.GetEventByID(*promo.EventID); err != nil {
+			return errors.New("event not found")
+		}
+	}
+	return nil
+}
+
+// applyPromoCode memvalidasi kode promo lalu memotong harga tiket yang termasuk cakupannya.
+// Dipanggil di dalam transaksi setelah tiket dibuat; kode dikunci agar batas pemakaian tidak terlampaui.
+// Satu order dihitung sebagai satu pemakaian berapa pun jumlah tiketnya.
+func applyPromoCode(repos repository.Repositories, code string, userID uint, tickets []entity.Ticket, now time.Time) ([]entity.Ticket, error) {
+	code = entity.NormalizePromoCode(code)
+	if code == "" {
+		return tickets, nil
+	}
+
+	promo, err := repos.PromoCodes.GetPromoCodeByCodeForUpdate(code)
+	if err != nil {
+		return nil, ErrPromoCodeNotFound
+	}
+	if !promo.IsValidAt(now) {
+		return nil, ErrPromoCodeNotActive
+	}
+	if promo.MaxUses > 0 {
+		used, err := repos.PromoCodes.CountRedemptions(promo.ID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= int64(promo.MaxUses) {
+			return nil, ErrPromoCodeUsedUp
+		}
+	}
+	if promo.MaxUsesPerUser > 0 {
+		used, err := repos.PromoCodes.CountUserRedemptions(promo.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= int64(promo.MaxUsesPerUser) {
+			return nil, ErrPromoCodeUserLimit
+		}
+	}
+
+	// Potongan nominal berlaku sekali per order, hanya untuk tiket bermata uang sama, dan dibagi ke tiket sesuai urutan
+	remaining := promo.DiscountAmount
+	applied := false
+	for i, ticket := range tickets {
+		if !promo.AppliesTo(ticket.EventID, ticket.TicketTypeID) || ticket.Quantity <= 0 {
+			continue
+		}
+
+		discount := money.Zero(ticket.Price.Currency)
+		switch promo.DiscountType {
+		case "percentage":
+			discount = ticket.Price.Percent(promo.Value)
+		case "fixed":
+			if !remaining.SameCurrency(ticket.Price) {
+				continue
+			}
+			discount = remaining.Min(ticket.Price)
+			remaining = remaining.Sub(discount)
+		case "buy_x_get_y":
+			// Setiap kelipatan (X+Y) kursi, Y kursi di baris ini gratis
+			free := ticket.Quantity / (promo.BuyQuantity + promo.GetQuantity) * promo.GetQuantity
+			discount = ticket.Price.Share(free, ticket.Quantity)
+		}
+		if !discount.IsPositive() {
+			continue
+		}
+
+		tickets[i].Discount = discount
+		tickets[i].Price = ticket.Price.Sub(discount)
+		tickets[i].PromoCodeID = &promo.ID
+		if err := repos.Tickets.ApplyTicketDiscount(ticket.ID, tickets[i].Price, discount, promo.ID); err != nil {
+			return nil, err
+		}
+		applied = true
+	}
+
+	if !applied {
+		return nil, ErrPromoCodeNotApplicable
+	}
+	return tickets, nil
+}
@@ -1,50 +1,146 @@
 package service
 
 import (
+	"errors"
+	"eventix/money"
 	"eventix/repository"
 )
 
 type ReportService interface {
-	GetSummaryReport(page int, size int) (map[string]interface{}, error) // Update untuk mendukung pagination
+	GetSummaryReport(page int, size int) (map[string]interface{}, error)             // Update untuk mendukung pagination
 	GetEventReport(eventID uint, page int, size int) (map[string]interface{}, error) // Update untuk mendukung pagination
+	GetSeriesReport(seriesID uint) (map[string]interface{}, error)
+	GetPromoCodeReport() (map[string]interface{}, error)
 }
 
-
 type reportService struct {
 	ticketRepo repository.TicketRepository
+	eventRepo  repository.EventRepository
+	seriesRepo repository.EventSeriesRepository
+	promoRepo  repository.PromoCodeRepository
 }
 
-func NewReportService(ticketRepo repository.TicketRepository) ReportService {
+func NewReportService(ticketRepo repository.TicketRepository, eventRepo repository.EventRepository, seriesRepo repository.EventSeriesRepository, promoRepo repository.PromoCodeRepository) ReportService {
 	return &reportService{
 		ticketRepo: ticketRepo,
+		eventRepo:  eventRepo,
+		seriesRepo: seriesRepo,
+		promoRepo:  promoRepo,
 	}
 }
 
 func (s *reportService) GetEventReport(eventID uint, page int, size int) (map[string]interface{}, error) {
+	event, err := s.eventRepo.GetEventByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
 	tickets, totalItems, err := s.ticketRepo.GetEventReport(eventID, page, size)
 	if err != nil {
 		return nil, err
 	}
+	revenue, err := s.ticketRepo.GetRevenueByCurrency(&eventID)
+	if err != nil {
+		return nil, err
+	}
 
 	return map[string]interface{}{
-		"tickets":      tickets,
-		"total_items":  totalItems,
-		"current_page": page,
-		"page_size":    size,
+		"capacity":       event.Capacity,
+		"sold_count":     event.SoldCount,
+		"reserved_count": event.ReservedCount,
+		"available":      event.AvailableCapacity(),
+		"total_revenue":  revenue,
+		"tickets":        tickets,
+		"total_items":    totalItems,
+		"current_page":   page,
+		"page_size":      size,
 	}, nil
 }
 
-
 func (s *reportService) GetSummaryReport(page int, size int) (map[string]interface{}, error) {
 	tickets, totalItems, err := s.ticketRepo.GetSummaryReport(page, size)
 	if err != nil {
 		return nil, err
 	}
+	// Pendapatan dipisah per mata uang karena nilai beda mata uang tidak bisa dijumlahkan
+	revenue, err := s.ticketRepo.GetRevenueByCurrency(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"tickets":       tickets,
+		"total_items":   totalItems,
+		"total_revenue": revenue,
+		"current_page":  page,
+		"page_size":     size,
+	}, nil
+}
+
+// GetSeriesReport menjumlahkan penjualan seluruh kemunculan seri beserta rincian per kemunculan
+func (s *reportService) GetSeriesReport(seriesID uint) (map[string]interface{}, error) {
+	series, err := s.seriesRepo.GetSeriesByID(seriesID)
+	if err != nil {
+		return nil, errors.New("event series not found")
+	}
+
+	occurrences, err := s.seriesRepo.GetOccurrenceStats(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	var capacity, soldCount, reservedCount int
+	var ticketsSold int64
+	revenue := money.Totals{}
+	statusCounts := map[string]int{}
+	for _, occurrence := range occurrences {
+		capacity += occurrence.Capacity
+		soldCount += occurrence.SoldCount
+		reservedCount += occurrence.ReservedCount
+		ticketsSold += occurrence.TicketsSold
+		revenue.Add(occurrence.Revenue)
+		statusCounts[occurrence.Status]++
+	}
+
+	return map[string]interface{}{
+		"series_id":         series.ID,
+		"name":              series.Name,
+		"occurrence_count":  len(occurrences),
+		"occurrence_status": statusCounts,
+		"capacity":          capacity,
+		"sold_count":        soldCount,
+		"reserved_count":    reservedCount,
+		"available":         capacity - soldCount - reservedCount,
+		"tickets_sold":      ticketsSold,
+		"total_revenue":     revenue.List(),
+		"occurrences":       occurrences,
+	}, nil
+}
+
+// GetPromoCodeReport merangkum pemakaian, total diskon dan pendapatan setiap kode promo dari order yang sudah dibayar
+func (s *reportService) GetPromoCodeReport() (map[string]interface{}, error) {
+	codes, err := s.promoRepo.GetRedemptionStats()
+	if err != nil {
+		return nil, err
+	}
+
+	var redemptions int64
+	discount := money.Totals{}
+	revenue := money.Totals{}
+	for _, code := range codes {
+		redemptions += code.Redemptions
+		// Kode yang belum pernah dipakai tidak memiliki mata uang
+		if code.Revenue.Currency == "" {
+			continue
+		}
+		discount.Add(code.TotalDiscount)
+		revenue.Add(code.Revenue)
+	}
 
 	return map[string]interface{}{
-		"tickets":      tickets,
-		"total_items":  totalItems,
-		"current_page": page,
-		"page_size":    size,
+		"redemptions":    redemptions,
+		"total_discount": discount.List(),
+		"total_revenue":  revenue.List(),
+		"promo_codes":    codes,
 	}, nil
 }
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/repository"
+	"eventix/ticketcode"
+	"time"
+)
+
+type ReservationService interface {
+	GetReservationByID(id uint, userID uint) (entity.Reservation, error)
+	CreateReservation(reservation entity.Reservation) (entity.Reservation, error)
+	ConfirmReservation(id uint, userID uint, promoCode string) (entity.Ticket, error) // Mengubah reservasi menjadi tiket pending_payment
+	ReleaseExpiredReservations() (int, error)
+}
+
+type reservationService struct {
+	txManager repository.TxManager
+	repo      repository.ReservationRepository
+	payments  PaymentService
+	ttl       time.Duration
+	pricing   OrderPricing
+}
+
+func NewReservationService(txManager repository.TxManager, repo repository.ReservationRepository, payments PaymentService, ttl time.Duration, pricing OrderPricing) ReservationService {
+	return &reservationService{
+		txManager: txManager,
+		repo:      repo,
+		payments:  payments,
+		ttl:       ttl,
+		pricing:   pricing,
+	}
+}
+
+func (s *reservationService) GetReservationByID(id uint, userID uint) (entity.Reservation, error) {
+	reservation, err := s.repo.GetReservationByID(id)
+	if err != nil || reservation.UserID != userID {
+		return entity.Reservation{}, errors.New("reservation not found")
+	}
+	return reservation, nil
+}
+
+func (s *reservationService) CreateReservation(reservation entity.Reservation) (entity.Reservation, error) {
+	var created entity.Reservation
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Ambil dan kunci data event terkait
+		event, err := repos.Events.GetEventByIDForUpdate(reservation.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		// Validasi jendela penjualan
+		if err := checkSalesWindow(event, time.Now()); err != nil {
+			return err
+		}
+
+		// Validasi batas pembelian per pesanan dan per user
+		if err := checkPurchaseLimits(repos, event, reservation.UserID, reservation.Quantity); err != nil {
+			return err
+		}
+
+		// Validasi kapasitas (kursi yang ditahan reservasi lain sudah diperhitungkan)
+		if reservation.Quantity > event.AvailableCapacity() {
+			return ErrCapacityExceeded
+		}
+
+		// Validasi kategori tiket
+		if _, err := resolveUnitPrice(repos, event, reservation.TicketTypeID, reservation.Quantity); err != nil {
+			return err
+		}
+
+		// Tahan kursi
+		if err := repos.Events.AddReservedCount(event.ID, reservation.Quantity); err != nil {
+			return errors.New("failed to update event capacity")
+		}
+		if reservation.TicketTypeID != nil {
+			if err := repos.TicketTypes.AddReservedCount(*reservation.TicketTypeID, reservation.Quantity); err != nil {
+				return errors.New("failed to update ticket type quota")
+			}
+		}
+
+		reservation.ID = 0
+		reservation.Status = "held"
+		reservation.TicketID = nil
+		reservation.ExpiresAt = time.Now().Add(s.ttl)
+		created, err = repos.Reservations.CreateReservation(reservation)
+		return err
+	})
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	return created, nil
+}
+
+func (s *reservationService) ConfirmReservation(id uint, userID uint, promoCode string) (entity.Ticket, error) {
+	var order entity.Order
+	expired := false
+	var closedErr error
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Ambil dan kunci reservasi agar tidak dikonfirmasi dua kali atau dilepas sweeper bersamaan
+		reservation, err := repos.Reservations.GetReservationByIDForUpdate(id)
+		if err != nil || reservation.UserID != userID {
+			return errors.New("reservation not found")
+		}
+
+		if reservation.Status != "held" {
+			return errors.New("reservation is no longer active")
+		}
+
+		// Reservasi kedaluwarsa dilepas sekarang tanpa menunggu sweeper
+		if !reservation.ExpiresAt.After(time.Now()) {
+			expired = true
+			return releaseReservation(repos, reservation, "expired")
+		}
+
+		event, err := repos.Events.GetEventByIDForUpdate(reservation.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+		// Event yang dibatalkan atau penjualannya sudah ditutup tidak boleh menerbitkan tiket; kursinya dilepas
+		if closedErr = checkSalesWindow(event, time.Now()); closedErr != nil {
+			return releaseReservation(repos, reservation, "cancelled")
+		}
+
+		// Hitung harga saat konfirmasi, termasuk aturan harga dinamis; kuota sudah dijamin oleh reservasi
+		unitPrice := event.Price
+		if reservation.TicketTypeID != nil {
+			ticketType, err := repos.TicketTypes.GetTicketTypeByIDForUpdate(*reservation.TicketTypeID)
+			if err != nil {
+				return errors.New("ticket type not found")
+			}
+			unitPrice = ticketType.Price
+		}
+		quote, err := dynamicUnitPrice(repos.PricingRules, repos.TicketTypes, event, reservation.TicketTypeID, unitPrice, time.Now())
+		if err != nil {
+			return err
+		}
+
+		code, err := ticketcode.NewCode()
+		if err != nil {
+			return err
+		}
+		items, err := buildTicketItems(reservation.EventID, reservation.Quantity, nil)
+		if err != nil {
+			return err
+		}
+
+		// Kursi tetap ditahan, kini oleh tiket, sampai pembayaran selesai
+		createdTicket, err := repos.Tickets.CreateTicket(entity.Ticket{
+			EventID:       reservation.EventID,
+			TicketTypeID:  reservation.TicketTypeID,
+			UserID:        reservation.UserID,
+			Code:          code,
+			Quantity:      reservation.Quantity,
+			Price:         quote.UnitPrice.Mul(reservation.Quantity),
+			PricingRuleID: pricingRuleID(quote),
+			Status:        "pending_payment",
+			Items:         items,
+		})
+		if err != nil {
+			return err
+		}
+
+		tickets, err := applyPromoCode(repos, promoCode, reservation.UserID, []entity.Ticket{createdTicket}, time.Now())
+		if err != nil {
+			return err
+		}
+		if order, err = createOrder(repos, s.pricing, reservation.UserID, tickets, s.payments.PaymentDeadline(time.Now())); err != nil {
+			return err
+		}
+
+		if err := repos.Reservations.UpdateReservationStatus(reservation.ID, "confirmed", &createdTicket.ID); err != nil {
+			return err
+		}
+		// Tawaran dari waitlist selesai setelah reservasinya dikonfirmasi
+		return repos.Waitlist.UpdateEntryStatusByReservation(reservation.ID, "fulfilled")
+	})
+	if err != nil {
+		return entity.Ticket{}, err
+	}
+	if expired {
+		return entity.Ticket{}, errors.New("reservation has expired")
+	}
+	if closedErr != nil {
+		return entity.Ticket{}, closedErr
+	}
+
+	order, err = s.payments.StartOrderPayment(order)
+	if err != nil {
+		return entity.Ticket{}, err
+	}
+	return order.Tickets[0], nil
+}
+
+// ReleaseExpiredReservations mengembalikan kursi dari reservasi kedaluwarsa ke ketersediaan event
+func (s *reservationService) ReleaseExpiredReservations() (int, error) {
+	ids, err := s.repo.GetExpiredReservationIDs(time.Now(), 100)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, id := range ids {
+		skipped := false
+		err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+			reservation, err := repos.Reservations.GetReservationByIDForUpdate(id)
+			if err != nil {
+				return err
+			}
+
+			// Lewati jika sudah dikonfirmasi atau dilepas di antara query dan lock
+			if reservation.Status != "held" || reservation.ExpiresAt.After(time.Now()) {
+				skipped = true
+				return nil
+			}
+
+			return releaseReservation(repos, reservation, "expired")
+		})
+		if err != nil {
+			return released, err
+		}
+		// Dihitung setelah commit agar pelepasan yang gagal tidak dilaporkan
+		if !skipped {
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// releaseReservation melepas kursi yang ditahan dan menandai reservasi dengan status akhir
+func releaseReservation(repos repository.Repositories, reservation entity.Reservation, status string) error {
+	if err := repos.Events.AddReservedCount(reservation.EventID, -reservation.Quantity); err != nil {
+		return errors.New("failed to update event capacity")
+	}
+	if reservation.TicketTypeID != nil {
+		if err := repos.TicketTypes.AddReservedCount(*reservation.TicketTypeID, -reservation.Quantity); err != nil {
+			return errors.New("failed to update ticket type quota")
+		}
+	}
+	if err := repos.Reservations.UpdateReservationStatus(reservation.ID, status, nil); err != nil {
+		return err
+	}
+	// Tawaran waitlist yang tidak dikonfirmasi ikut berakhir
+	return repos.Waitlist.UpdateEntryStatusByReservation(reservation.ID, "expired")
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/repository"
+	"testing"
+	"time"
+)
+
+// Reservasi untuk event yang dibatalkan tidak bisa dikonfirmasi dan kursinya langsung dilepas
+func TestConfirmReservationRejectsClosedEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event entity.Event
+		want  error
+	}{
+		{"cancelled", entity.Event{Status: "cancelled", EndDate: time.Now().Add(24 * time.Hour)}, ErrEventNotAvailable},
+		{"draft", entity.Event{Status: "draft", EndDate: time.Now().Add(24 * time.Hour)}, ErrEventNotAvailable},
+		{"sales ended", entity.Event{Status: "active", EndDate: time.Now().Add(-time.Hour)}, ErrSalesEnded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			event.ID = 1
+			event.Capacity = 10
+			event.ReservedCount = 2
+			event.Price = money.New(10000, "IDR")
+			event.StartDate = event.EndDate.Add(-3 * time.Hour)
+			events := newFakeEventRepo(event)
+			reservations := &fakeReservationRepo{reservations: map[uint]entity.Reservation{
+				7: {ID: 7, EventID: 1, UserID: 10, Quantity: 2, Status: "held", ExpiresAt: time.Now().Add(time.Hour)},
+			}}
+			waitlist := &fakeWaitlistRepo{byReservation: map[uint]string{}}
+			tx := &fakeTx{repos: repository.Repositories{Events: events, Reservations: reservations, Waitlist: waitlist}}
+			svc := NewReservationService(tx, reservations, nil, time.Hour, OrderPricing{})
+
+			if _, err := svc.ConfirmReservation(7, 10, ""); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if got := reservations.reservations[7].Status; got != "cancelled" {
+				t.Errorf("reservation status %q, want cancelled", got)
+			}
+			if got := events.events[1].ReservedCount; got != 0 {
+				t.Errorf("event still reserves %d seats, want 0", got)
+			}
+			if got := waitlist.byReservation[7]; got != "expired" {
+				t.Errorf("waitlist offer status %q, want expired", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"eventix/config"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/payment"
+	"eventix/repository"
+	"eventix/ticketcode"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB menghubungkan ke database MySQL khusus pengujian dari TEST_DB_DSN.
+// Pengujian yang bergantung pada penguncian baris dilewati jika variabel itu tidak diisi.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set; skipping MySQL integration test")
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	if err := config.Migrate(db); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+// cleanupEvent menghapus event uji beserta tiket, order, dan invoice yang dibuat untuknya
+func cleanupEvent(t *testing.T, db *gorm.DB, eventID uint) {
+	t.Helper()
+	t.Cleanup(func() {
+		ticketIDs := db.Model(&entity.Ticket{}).Select("id").Where("event_id = ?", eventID)
+		orderIDs := db.Model(&entity.Ticket{}).Select("order_id").Where("event_id = ? AND order_id IS NOT NULL", eventID)
+		invoiceIDs := db.Model(&entity.Invoice{}).Select("id").Where("order_id IN (?)", orderIDs)
+
+		steps := []*gorm.DB{
+			db.Where("invoice_id IN (?)", invoiceIDs).Delete(&entity.InvoiceLine{}),
+			db.Where("order_id IN (?)", orderIDs).Delete(&entity.Invoice{}),
+			db.Where("id IN (?)", orderIDs).Delete(&entity.Order{}),
+			db.Where("ticket_id IN (?)", ticketIDs).Delete(&entity.Refund{}),
+			db.Where("ticket_id IN (?)", ticketIDs).Delete(&entity.TicketItem{}),
+			db.Unscoped().Where("event_id = ?", eventID).Delete(&entity.Ticket{}),
+			db.Unscoped().Delete(&entity.Event{}, eventID),
+		}
+		for _, step := range steps {
+			if step.Error != nil {
+				t.Errorf("failed to clean up event %d: %v", eventID, step.Error)
+			}
+		}
+	})
+}
+
+// Pembelian paralel yang melebihi kapasitas hanya boleh menjual sebanyak kapasitas event
+func TestCreateTicketDoesNotOversell(t *testing.T) {
+	db := openTestDB(t)
+
+	const capacity = 20
+	const buyers = 200
+
+	// Batasi koneksi di bawah max_connections MySQL; pembeli lain menunggu koneksi dan tetap berebut lock event
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(50)
+
+	event := entity.Event{
+		Name:      fmt.Sprintf("Concurrency test %d", time.Now().UnixNano()),
+		StartDate: time.Now().Add(30 * 24 * time.Hour),
+		EndDate:   time.Now().Add(31 * 24 * time.Hour),
+		Capacity:  capacity,
+		Price:     money.New(10000, "IDR"),
+		Status:    "active",
+	}
+	if err := db.Create(&event).Error; err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	cleanupEvent(t, db, event.ID)
+
+	txManager := repository.NewTxManager(db)
+	provider := payment.NewFakeProvider("test")
+	payments := NewPaymentService(txManager, repository.NewOrderRepository(db), repository.NewRefundRepository(db), provider, time.Hour)
+	svc := NewTicketService(txManager, repository.NewTicketRepository(db), repository.NewEventRepository(db), payments, nil, ticketcode.NewSigner("test"), OrderPricing{})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var sold []entity.Ticket
+	failures := map[uint]error{}
+	start := make(chan struct{})
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(userID uint) {
+			defer wg.Done()
+			<-start
+			ticket, err := svc.CreateTicket(entity.Ticket{EventID: event.ID, UserID: userID, Quantity: 1})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[userID] = err
+				return
+			}
+			sold = append(sold, ticket)
+		}(uint(1000 + i))
+	}
+	close(start)
+	wg.Wait()
+
+	// Pembeli yang gagal harus ditolak karena kapasitas habis, bukan karena deadlock atau timeout lock
+	for userID, err := range failures {
+		if !errors.Is(err, ErrCapacityExceeded) {
+			t.Errorf("buyer %d failed with %v, want %v", userID, err, ErrCapacityExceeded)
+		}
+	}
+	if len(sold) != capacity || len(failures) != buyers-capacity {
+		t.Fatalf("%d purchases succeeded and %d failed, want %d and %d", len(sold), len(failures), capacity, buyers-capacity)
+	}
+
+	// Selesaikan pembayaran semua tiket yang berhasil dipesan
+	for _, ticket := range sold {
+		payload, signature, err := provider.Simulate(ticket.PaymentIntentID, true)
+		if err != nil {
+			t.Fatalf("failed to simulate payment: %v", err)
+		}
+		if err := payments.HandleWebhook(payload, signature); err != nil {
+			t.Fatalf("failed to handle webhook: %v", err)
+		}
+	}
+
+	var saved entity.Event
+	if err := db.First(&saved, event.ID).Error; err != nil {
+		t.Fatalf("failed to reload event: %v", err)
+	}
+	if saved.SoldCount != capacity || saved.ReservedCount != 0 {
+		t.Errorf("sold %d and reserved %d seats, want %d sold and none reserved", saved.SoldCount, saved.ReservedCount, capacity)
+	}
+
+	var purchased int64
+	db.Model(&entity.Ticket{}).Where("event_id = ? AND status = ?", event.ID, "purchased").Count(&purchased)
+	if purchased != capacity {
+		t.Errorf("%d purchased tickets, want %d", purchased, capacity)
+	}
+}
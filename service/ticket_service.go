@@ -3,16 +3,25 @@ package service
 import (
 	"errors"
 	"eventix/entity"
+	"eventix/money"
+	"eventix/qrcode"
 	"eventix/repository"
+	"eventix/ticketcode"
+	"time"
 )
 
 type TicketService interface {
     GetAllTickets(page int, size int) ([]entity.Ticket, error)
     GetTicketsByUserID(userID uint, page int, size int) ([]entity.Ticket, int64, error) // Perbaikan di sini
     GetTicketByID(id uint) (entity.Ticket, error)
+    GetTicketForUser(id uint, actorID uint, actorRole string) (entity.Ticket, error)
+    GetTicketQR(id uint, actorID uint, actorRole string) ([]byte, error)
     CreateTicket(ticket entity.Ticket) (entity.Ticket, error)
     UpdateTicket(ticket entity.Ticket) (entity.Ticket, error)
-    CancelTicket(ticketID uint) error
+    CancelTicket(ticketID uint, actorID uint, actorRole string, reason string) (entity.Refund, error)
+    CancelTicketItem(ticketID uint, itemID uint, actorID uint, actorRole string, reason string) (entity.Refund, error)
+    UpdateTicketItemHolder(ticketID uint, itemID uint, actorID uint, actorRole string, holderName string, holderEmail string) (entity.TicketItem, error)
+    GetTicketItemQR(ticketID uint, itemID uint, actorID uint, actorRole string) ([]byte, error)
     UpdateTicketStatus(id uint, status string) error
     SearchTickets(status string) ([]entity.Ticket, error)
     GetPaginatedTickets(page int, size int) ([]entity.Ticket, error)
@@ -22,12 +31,17 @@ type TicketService interface {
 
 
 type ticketService struct {
+	txManager repository.TxManager
 	repo      repository.TicketRepository
 	eventRepo repository.EventRepository
+	payments  PaymentService
+	waitlist  WaitlistService
+	signer    *ticketcode.Signer
+	pricing   OrderPricing
 }
 
-func NewTicketService(repo repository.TicketRepository, eventRepo repository.EventRepository) TicketService {
-	return &ticketService{repo: repo, eventRepo: eventRepo}
+func NewTicketService(txManager repository.TxManager, repo repository.TicketRepository, eventRepo repository.EventRepository, payments PaymentService, waitlist WaitlistService, signer *ticketcode.Signer, pricing OrderPricing) TicketService {
+	return &ticketService{txManager: txManager, repo: repo, eventRepo: eventRepo, payments: payments, waitlist: waitlist, signer: signer, pricing: pricing}
 }
 
 func (s *ticketService) GetAllTickets(page int, size int) ([]entity.Ticket, error) {
@@ -43,61 +57,594 @@ func (s *ticketService) GetTicketByID(id uint) (entity.Ticket, error) {
 	return s.repo.GetTicketByID(id)
 }
 
+// GetTicketForUser mengambil tiket milik actor; Admin boleh mengakses tiket siapa pun
+func (s *ticketService) GetTicketForUser(id uint, actorID uint, actorRole string) (entity.Ticket, error) {
+	ticket, err := s.repo.GetTicketByID(id)
+	if err != nil {
+		return entity.Ticket{}, ErrTicketNotFound
+	}
+	if err := authorizeTicketAccess(ticket, actorID, actorRole); err != nil {
+		return entity.Ticket{}, err
+	}
+	return ticket, nil
+}
+
+// GetTicketQR merender payload tiket bertanda tangan menjadi PNG QR code
+func (s *ticketService) GetTicketQR(id uint, actorID uint, actorRole string) ([]byte, error) {
+	ticket, err := s.GetTicketForUser(id, actorID, actorRole)
+	if err != nil {
+		return nil, err
+	}
+
+	// QR hanya tersedia untuk tiket yang sudah dibayar
+	if ticket.Status != "purchased" || ticket.Code == "" {
+		return nil, errors.New("QR code is only available for purchased tickets")
+	}
+
+	return qrcode.PNG([]byte(s.signer.Payload(ticket.EventID, ticket.Code)), 8)
+}
+
+// GetTicketItemQR merender payload bertanda tangan untuk satu kursi menjadi PNG QR code
+func (s *ticketService) GetTicketItemQR(ticketID uint, itemID uint, actorID uint, actorRole string) ([]byte, error) {
+	ticket, err := s.GetTicketForUser(ticketID, actorID, actorRole)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status != "purchased" {
+		return nil, errors.New("QR code is only available for purchased tickets")
+	}
+
+	item, err := findTicketItem(ticket, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != "active" {
+		return nil, errors.New("ticket item has been cancelled")
+	}
+
+	return qrcode.PNG([]byte(s.signer.Payload(ticket.EventID, item.Code)), 8)
+}
+
+// UpdateTicketItemHolder mengubah nama dan email pemegang satu kursi
+func (s *ticketService) UpdateTicketItemHolder(ticketID uint, itemID uint, actorID uint, actorRole string, holderName string, holderEmail string) (entity.TicketItem, error) {
+	ticket, err := s.GetTicketForUser(ticketID, actorID, actorRole)
+	if err != nil {
+		return entity.TicketItem{}, err
+	}
+
+	item, err := findTicketItem(ticket, itemID)
+	if err != nil {
+		return entity.TicketItem{}, err
+	}
+	if item.Status != "active" {
+		return entity.TicketItem{}, errors.New("ticket item has been cancelled")
+	}
+
+	if err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		return repos.TicketItems.UpdateItemHolder(item.ID, holderName, holderEmail)
+	}); err != nil {
+		return entity.TicketItem{}, err
+	}
+
+	item.HolderName = holderName
+	item.HolderEmail = holderEmail
+	return item, nil
+}
+
+func findTicketItem(ticket entity.Ticket, itemID uint) (entity.TicketItem, error) {
+	for _, item := range ticket.Items {
+		if item.ID == itemID {
+			return item, nil
+		}
+	}
+	return entity.TicketItem{}, ErrTicketItemNotFound
+}
+
+// authorizeTicketAccess memastikan actor adalah pemilik tiket atau Admin
+func authorizeTicketAccess(ticket entity.Ticket, actorID uint, actorRole string) error {
+	if actorRole == "Admin" || ticket.UserID == actorID {
+		return nil
+	}
+	return ErrTicketForbidden
+}
+
+// CreateTicket membeli langsung tiket untuk satu event sebagai order satu baris
 func (s *ticketService) CreateTicket(ticket entity.Ticket) (entity.Ticket, error) {
-	// Ambil data event terkait
-	event, err := s.eventRepo.GetEventByID(ticket.EventID)
+	var order entity.Order
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		createdTicket, err := reserveTicket(repos, ticket)
+		if err != nil {
+			return err
+		}
+		tickets, err := applyPromoCode(repos, ticket.PromoCode, ticket.UserID, []entity.Ticket{createdTicket}, time.Now())
+		if err != nil {
+			return err
+		}
+		order, err = createOrder(repos, s.pricing, ticket.UserID, tickets, s.payments.PaymentDeadline(time.Now()))
+		return err
+	})
+	if err != nil {
+		return entity.Ticket{}, err
+	}
+
+	// Mulai pembayaran di luar transaksi agar lock tidak tertahan selama memanggil provider
+	order, err = s.payments.StartOrderPayment(order)
+	if err != nil {
+		return entity.Ticket{}, err
+	}
+	return order.Tickets[0], nil
+}
+
+// reserveTicket memvalidasi pembelian lalu membuat tiket pending_payment yang menahan kursinya.
+// Dipanggil di dalam transaksi; event dan kategori tiket dikunci selama validasi.
+func reserveTicket(repos repository.Repositories, ticket entity.Ticket) (entity.Ticket, error) {
+	// Mode kursi pilihan: jumlah kursi yang dipilih menjadi jumlah tiket
+	if len(ticket.SeatIDs) > 0 {
+		if ticket.Quantity == 0 {
+			ticket.Quantity = len(ticket.SeatIDs)
+		}
+		if ticket.Quantity != len(ticket.SeatIDs) {
+			return entity.Ticket{}, errors.New("quantity must match the number of selected seats")
+		}
+	}
+
+	// Ambil dan kunci data event terkait agar pembelian paralel menunggu giliran
+	event, err := repos.Events.GetEventByIDForUpdate(ticket.EventID)
 	if err != nil {
 		return entity.Ticket{}, errors.New("event not found")
 	}
 
+	// Validasi jendela penjualan
+	if err := checkSalesWindow(event, time.Now()); err != nil {
+		return entity.Ticket{}, err
+	}
+
+	// Validasi batas pembelian per pesanan dan per user
+	if err := checkPurchaseLimits(repos, event, ticket.UserID, ticket.Quantity); err != nil {
+		return entity.Ticket{}, err
+	}
+
 	// Validasi kapasitas
-	if ticket.Quantity > event.Capacity {
-		return entity.Ticket{}, errors.New("quantity exceeds event capacity")
+	if ticket.Quantity > event.AvailableCapacity() {
+		return entity.Ticket{}, ErrCapacityExceeded
 	}
 
-	// Hitung harga tiket berdasarkan harga event
-	ticket.Price = float64(ticket.Quantity) * event.Price
-	ticket.Status = "purchased"
+	// Hitung harga tiket berdasarkan harga event atau kategori tiket
+	unitPrice, err := resolveUnitPrice(repos, event, ticket.TicketTypeID, ticket.Quantity)
+	if err != nil {
+		return entity.Ticket{}, err
+	}
 
-	// Kurangi kapasitas event
-	event.Capacity -= ticket.Quantity
-	_, err = s.eventRepo.UpdateEvent(event)
+	// Terapkan aturan harga dinamis dan catat aturan yang dipakai
+	quote, err := dynamicUnitPrice(repos.PricingRules, repos.TicketTypes, event, ticket.TicketTypeID, unitPrice, time.Now())
 	if err != nil {
+		return entity.Ticket{}, err
+	}
+	ticket.Price = quote.UnitPrice.Mul(ticket.Quantity)
+	ticket.PricingRuleID = pricingRuleID(quote)
+	ticket.Discount = money.Zero(ticket.Price.Currency)
+	ticket.PromoCodeID = nil
+	ticket.Status = "pending_payment"
+	ticket.PaymentIntentID = ""
+	ticket.OrderID = nil
+	if ticket.Code, err = ticketcode.NewCode(); err != nil {
+		return entity.Ticket{}, err
+	}
+
+	// Satu item per kursi beserta data pemegangnya
+	if ticket.Items, err = buildTicketItems(event.ID, ticket.Quantity, ticket.Items); err != nil {
+		return entity.Ticket{}, err
+	}
+	if len(ticket.SeatIDs) > 0 {
+		if err := validateSeatSelection(repos, event, ticket.SeatIDs); err != nil {
+			return entity.Ticket{}, err
+		}
+		for i := range ticket.Items {
+			ticket.Items[i].SeatID = &ticket.SeatIDs[i]
+		}
+	}
+
+	// Tahan kursi sampai pembayaran selesai
+	if err := repos.Events.AddReservedCount(event.ID, ticket.Quantity); err != nil {
 		return entity.Ticket{}, errors.New("failed to update event capacity")
 	}
+	if ticket.TicketTypeID != nil {
+		if err := repos.TicketTypes.AddReservedCount(*ticket.TicketTypeID, ticket.Quantity); err != nil {
+			return entity.Ticket{}, errors.New("failed to update ticket type quota")
+		}
+	}
 
 	// Buat tiket
-	return s.repo.CreateTicket(ticket)
+	createdTicket, err := repos.Tickets.CreateTicket(ticket)
+	if err != nil {
+		return entity.Ticket{}, err
+	}
+
+	// Ambil kursi pilihan setelah item tersimpan agar setiap kursi terhubung ke item-nya
+	if err := lockSelectedSeats(repos, createdTicket); err != nil {
+		return entity.Ticket{}, err
+	}
+	return createdTicket, nil
+}
+
+
+func (s *ticketService) CancelTicket(ticketID uint, actorID uint, actorRole string, reason string) (entity.Refund, error) {
+	var refund entity.Refund
+	var eventID uint
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		ticket, event, err := lockCancellableTicket(repos, ticketID, actorID, actorRole)
+		if err != nil {
+			return err
+		}
+
+		// Batalkan semua kursi yang masih aktif
+		items, err := repos.TicketItems.GetItemsByTicketIDForUpdate(ticket.ID)
+		if err != nil {
+			return err
+		}
+		var activeItems []entity.TicketItem
+		for _, item := range items {
+			if item.Status == "active" {
+				activeItems = append(activeItems, item)
+			}
+		}
+		// Tiket lama tanpa item dianggap memiliki seluruh kursinya aktif
+		seats := ticket.Quantity
+		if len(items) > 0 {
+			seats = len(activeItems)
+		}
+		for _, item := range activeItems {
+			if err := repos.TicketItems.UpdateItemStatus(item.ID, "cancelled"); err != nil {
+				return err
+			}
+		}
+
+		// Update status tiket
+		if err := repos.Tickets.UpdateTicketStatus(ticket.ID, "cancelled"); err != nil {
+			return err
+		}
+		if err := repos.EventSeats.ReleaseSeatsByTicketID(ticket.ID); err != nil {
+			return err
+		}
+
+		eventID = event.ID
+		refund, err = releaseAndRefund(repos, ticket, event, seats, seats, nil, reason)
+		return err
+	})
+	if err != nil {
+		return entity.Refund{}, err
+	}
+
+	s.waitlist.OfferReleasedSeats(eventID)
+
+	// Refund dikirim ke provider setelah commit; yang gagal dicoba ulang oleh job refund-retry
+	return s.payments.SendRefund(refund)
 }
 
+// CancelTicketItem membatalkan satu kursi; tiket ikut dibatalkan jika tidak ada kursi aktif tersisa
+func (s *ticketService) CancelTicketItem(ticketID uint, itemID uint, actorID uint, actorRole string, reason string) (entity.Refund, error) {
+	var refund entity.Refund
+	var eventID uint
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		ticket, event, err := lockCancellableTicket(repos, ticketID, actorID, actorRole)
+		if err != nil {
+			return err
+		}
 
-func (s *ticketService) CancelTicket(ticketID uint) error {
-	// Ambil data tiket terkait
-	ticket, err := s.repo.GetTicketByID(ticketID)
+		items, err := repos.TicketItems.GetItemsByTicketIDForUpdate(ticket.ID)
+		if err != nil {
+			return err
+		}
+
+		var target *entity.TicketItem
+		remaining := 0
+		for i := range items {
+			if items[i].ID == itemID {
+				target = &items[i]
+			} else if items[i].Status == "active" {
+				remaining++
+			}
+		}
+		if target == nil {
+			return ErrTicketItemNotFound
+		}
+		if target.Status != "active" {
+			return errors.New("ticket item cannot be cancelled")
+		}
+
+		if err := repos.TicketItems.UpdateItemStatus(target.ID, "cancelled"); err != nil {
+			return err
+		}
+		if err := repos.EventSeats.ReleaseSeatByTicketItemID(target.ID); err != nil {
+			return err
+		}
+		if remaining == 0 {
+			if err := repos.Tickets.UpdateTicketStatus(ticket.ID, "cancelled"); err != nil {
+				return err
+			}
+		}
+
+		eventID = event.ID
+		refund, err = releaseAndRefund(repos, ticket, event, 1, remaining+1, &target.ID, reason)
+		return err
+	})
 	if err != nil {
-		return errors.New("ticket not found")
+		return entity.Refund{}, err
+	}
+
+	s.waitlist.OfferReleasedSeats(eventID)
+
+	// Refund dikirim ke provider setelah commit; yang gagal dicoba ulang oleh job refund-retry
+	return s.payments.SendRefund(refund)
+}
+
+// lockCancellableTicket mengunci tiket dan event-nya lalu memastikan tiket masih boleh dibatalkan oleh actor
+func lockCancellableTicket(repos repository.Repositories, ticketID uint, actorID uint, actorRole string) (entity.Ticket, entity.Event, error) {
+	// Ambil dan kunci data tiket terkait agar tidak dibatalkan dua kali
+	ticket, err := repos.Tickets.GetTicketByIDForUpdate(ticketID)
+	if err != nil {
+		return entity.Ticket{}, entity.Event{}, ErrTicketNotFound
+	}
+
+	// Validasi kepemilikan tiket
+	if err := authorizeTicketAccess(ticket, actorID, actorRole); err != nil {
+		return entity.Ticket{}, entity.Event{}, err
 	}
 
 	// Validasi status tiket
 	if ticket.Status != "purchased" {
-		return errors.New("ticket cannot be cancelled")
+		return entity.Ticket{}, entity.Event{}, errors.New("ticket cannot be cancelled")
+	}
+
+	// Ambil dan kunci data event terkait
+	event, err := repos.Events.GetEventByIDForUpdate(ticket.EventID)
+	if err != nil {
+		return entity.Ticket{}, entity.Event{}, errors.New("event not found")
+	}
+
+	// Validasi apakah event sudah berlangsung
+	if !time.Now().Before(event.StartDate) {
+		return entity.Ticket{}, entity.Event{}, errors.New("ticket cannot be cancelled because the event has already started")
+	}
+
+	return ticket, event, nil
+}
+
+// releaseAndRefund mengembalikan sejumlah kursi ke ketersediaan event dan mencatat refund sesuai kebijakan refund.
+// activeSeats adalah jumlah kursi tiket yang masih aktif sebelum pembatalan ini.
+func releaseAndRefund(repos repository.Repositories, ticket entity.Ticket, event entity.Event, seats int, activeSeats int, itemID *uint, reason string) (entity.Refund, error) {
+	// Kembalikan kursi ke ketersediaan event
+	if err := repos.Events.AddSoldCount(event.ID, -seats); err != nil {
+		return entity.Refund{}, errors.New("failed to update event capacity")
+	}
+	if ticket.TicketTypeID != nil {
+		if err := repos.TicketTypes.AddSoldCount(*ticket.TicketTypeID, -seats); err != nil {
+			return entity.Refund{}, errors.New("failed to update ticket type quota")
+		}
+	}
+
+	charged, remaining, err := ticketCharge(repos, ticket)
+	if err != nil {
+		return entity.Refund{}, err
+	}
+
+	// Hitung refund sesuai kebijakan event, sebanding dengan jumlah kursi yang dibatalkan.
+	// Bagian kursi dihitung kumulatif agar kursi yang dibatalkan satu per satu berjumlah tepat sebesar tagihan tiket.
+	percent := event.RefundPercent(time.Now())
+	seatsCharge := charged
+	if ticket.Quantity > 0 {
+		cancelledBefore := ticket.Quantity - activeSeats
+		seatsCharge = charged.Share(cancelledBefore+seats, ticket.Quantity).Sub(charged.Share(cancelledBefore, ticket.Quantity))
+	}
+	amount := seatsCharge.Percent(float64(percent)).Min(remaining)
+
+	// Refund dicatat pending di transaksi yang sama; pemanggil mengirimnya ke provider setelah commit.
+	// Tiket gratis atau refund nol tidak perlu melewati provider.
+	status := "pending"
+	if !amount.IsPositive() || ticket.PaymentIntentID == "" {
+		status = "succeeded"
+	}
+
+	return repos.Refunds.CreateRefund(entity.Refund{
+		TicketID:        ticket.ID,
+		TicketItemID:    itemID,
+		UserID:          ticket.UserID,
+		Amount:          amount,
+		Percent:         percent,
+		Reason:          reason,
+		Status:          status,
+		PaymentIntentID: ticket.PaymentIntentID,
+	})
+}
+
+// ticketCharge menghitung bagian tiket dari tagihan order: harga tiket setelah diskon ditambah bagian biaya layanan
+// dan pajaknya, beserta sisa pembayaran order yang belum dikembalikan. Tiket tanpa order (data lama) hanya ditagih harganya.
+func ticketCharge(repos repository.Repositories, ticket entity.Ticket) (charged money.Money, remaining money.Money, err error) {
+	if ticket.OrderID == nil {
+		return ticket.Price, ticket.Price, nil
+	}
+	order, err := repos.Orders.GetOrderByID(*ticket.OrderID)
+	if err != nil {
+		return money.Money{}, money.Money{}, ErrOrderNotFound
+	}
+
+	// Biaya layanan dihitung per kursi; pajak dibagi sebanding dengan dasar pajak tiket di dalam order
+	fee := order.ServiceFee.Share(ticket.Quantity, order.Quantity)
+	taxable := ticket.Price.Add(fee)
+	orderTaxable := order.Subtotal.Sub(order.Discount).Add(order.ServiceFee)
+	tax := money.Zero(order.Tax.Currency)
+	if orderTaxable.IsPositive() {
+		tax = order.Tax.Share(int(taxable.Amount), int(orderTaxable.Amount))
+	}
+
+	// Pembulatan per tiket tidak boleh membuat total refund order melebihi jumlah yang dibayar
+	refunded, err := repos.Refunds.SumRefundedByOrderID(order.ID)
+	if err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+	return taxable.Add(tax), order.Total.Sub(money.New(refunded, order.Total.Currency)), nil
+}
+
+// validateSeatSelection memastikan kursi yang dipilih milik venue event, tidak ganda, dan belum diambil
+func validateSeatSelection(repos repository.Repositories, event entity.Event, seatIDs []uint) error {
+	if event.VenueID == nil {
+		return errors.New("this event does not use assigned seating")
+	}
+
+	seen := make(map[uint]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		if seen[id] {
+			return errors.New("the same seat cannot be selected twice")
+		}
+		seen[id] = true
+	}
+
+	seats, err := repos.Venues.GetSeatsByIDs(seatIDs)
+	if err != nil {
+		return err
+	}
+	if len(seats) != len(seatIDs) {
+		return errors.New("one or more selected seats do not exist")
+	}
+	for _, seat := range seats {
+		if seat.VenueID != *event.VenueID {
+			return errors.New("one or more selected seats do not belong to this event's venue")
+		}
 	}
 
-	// Ambil data event terkait
-	event, err := s.eventRepo.GetEventByID(ticket.EventID)
+	taken, err := repos.EventSeats.GetTakenSeatIDs(event.ID)
 	if err != nil {
-		return errors.New("event not found")
+		return err
 	}
+	for _, id := range taken {
+		if seen[id] {
+			return ErrSeatUnavailable
+		}
+	}
+	return nil
+}
 
-	// Update kapasitas event
-	event.Capacity += ticket.Quantity
-	_, err = s.eventRepo.UpdateEvent(event)
+// lockSelectedSeats mengambil kursi tiket dalam satu insert; unique index (event_id, seat_id)
+// menolak kursi yang sudah diambil sehingga pilihan kursi berlaku semua atau tidak sama sekali
+func lockSelectedSeats(repos repository.Repositories, ticket entity.Ticket) error {
+	var eventSeats []entity.EventSeat
+	for _, item := range ticket.Items {
+		if item.SeatID == nil {
+			continue
+		}
+		eventSeats = append(eventSeats, entity.EventSeat{
+			EventID:      ticket.EventID,
+			SeatID:       *item.SeatID,
+			TicketID:     ticket.ID,
+			TicketItemID: item.ID,
+		})
+	}
+	if len(eventSeats) == 0 {
+		return nil
+	}
+	if err := repos.EventSeats.CreateEventSeats(eventSeats); err != nil {
+		return ErrSeatUnavailable
+	}
+	return nil
+}
+
+// buildTicketItems membuat satu item per kursi; data pemegang diambil dari attendees jika diisi
+func buildTicketItems(eventID uint, quantity int, attendees []entity.TicketItem) ([]entity.TicketItem, error) {
+	if len(attendees) > 0 && len(attendees) != quantity {
+		return nil, errors.New("number of attendees must match quantity")
+	}
+
+	items := make([]entity.TicketItem, quantity)
+	for i := range items {
+		code, err := ticketcode.NewCode()
+		if err != nil {
+			return nil, err
+		}
+		items[i] = entity.TicketItem{EventID: eventID, Code: code, Status: "active"}
+		if len(attendees) > 0 {
+			items[i].HolderName = attendees[i].HolderName
+			items[i].HolderEmail = attendees[i].HolderEmail
+		}
+	}
+	return items, nil
+}
+
+// checkSalesWindow menolak pembelian di luar jendela penjualan event
+func checkSalesWindow(event entity.Event, now time.Time) error {
+	if event.Status == "draft" || event.Status == "cancelled" {
+		return ErrEventNotAvailable
+	}
+	switch event.SalesStateAt(now) {
+	case "upcoming":
+		return ErrSalesNotStarted
+	case "ended":
+		return ErrSalesEnded
+	}
+	return nil
+}
+
+// checkPurchaseLimits memvalidasi jumlah kursi terhadap batas event; dipanggil setelah event dikunci
+// agar pesanan paralel dari user yang sama tidak lolos bersamaan
+func checkPurchaseLimits(repos repository.Repositories, event entity.Event, userID uint, quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if event.MinPerOrder > 0 && quantity < event.MinPerOrder {
+		return ErrBelowMinPerOrder
+	}
+	if event.MaxPerOrder > 0 && quantity > event.MaxPerOrder {
+		return ErrAboveMaxPerOrder
+	}
+	if event.MaxPerUser <= 0 {
+		return nil
+	}
+
+	// Kursi yang sudah dimiliki, menunggu pembayaran, atau masih ditahan reservasi ikut dihitung
+	owned, err := repos.Tickets.CountUserActiveSeats(userID, event.ID)
 	if err != nil {
-		return errors.New("failed to update event capacity")
+		return err
+	}
+	held, err := repos.Reservations.SumHeldQuantity(userID, event.ID)
+	if err != nil {
+		return err
+	}
+	if owned+held+int64(quantity) > int64(event.MaxPerUser) {
+		return ErrAboveMaxPerUser
+	}
+	return nil
+}
+
+// resolveUnitPrice memvalidasi kategori tiket yang dipilih dan mengembalikan harga satuannya.
+// Event tanpa kategori memakai harga event; event dengan kategori wajib memilih salah satunya.
+func resolveUnitPrice(repos repository.Repositories, event entity.Event, ticketTypeID *uint, quantity int) (money.Money, error) {
+	if ticketTypeID == nil {
+		ticketTypes, err := repos.TicketTypes.GetTicketTypesByEventID(event.ID)
+		if err != nil {
+			return money.Money{}, err
+		}
+		if len(ticketTypes) > 0 {
+			return money.Money{}, errors.New("ticket_type_id is required for this event")
+		}
+		return event.Price, nil
+	}
+
+	// Kunci kategori tiket agar kuota tidak terlampaui oleh pembelian paralel
+	ticketType, err := repos.TicketTypes.GetTicketTypeByIDForUpdate(*ticketTypeID)
+	if err != nil || ticketType.EventID != event.ID {
+		return money.Money{}, errors.New("ticket type not found")
+	}
+
+	if !ticketType.IsOnSale(time.Now()) {
+		return money.Money{}, errors.New("ticket type is not on sale")
+	}
+	if ticketType.MaxPerOrder > 0 && quantity > ticketType.MaxPerOrder {
+		return money.Money{}, errors.New("quantity exceeds ticket type limit per order")
+	}
+	if quantity > ticketType.AvailableQuota() {
+		return money.Money{}, errors.New("quantity exceeds ticket type quota")
 	}
 
-	// Update status tiket
-	return s.repo.UpdateTicketStatus(ticket.ID, "cancelled")
+	return ticketType.Price, nil
 }
 
 func (s *ticketService) UpdateTicketStatus(id uint, status string) error {
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/repository"
+	"eventix/ticketcode"
+	"testing"
+	"time"
+)
+
+func newOwnershipTestService() TicketService {
+	tickets := newFakeTicketRepo(entity.Ticket{
+		ID:       1,
+		EventID:  1,
+		UserID:   10,
+		Code:     "OWNERCODE",
+		Quantity: 2,
+		Status:   "purchased",
+		Items: []entity.TicketItem{
+			{ID: 101, TicketID: 1, EventID: 1, Code: "SEAT1", Status: "active"},
+			{ID: 102, TicketID: 1, EventID: 1, Code: "SEAT2", Status: "active"},
+		},
+	})
+	tx := &fakeTx{repos: repository.Repositories{Tickets: tickets}}
+	return NewTicketService(tx, tickets, nil, nil, nil, ticketcode.NewSigner("test"), OrderPricing{})
+}
+
+// Tiket milik user lain tidak boleh dibaca, diambil QR-nya, diubah, atau dibatalkan
+func TestTicketOwnershipIsEnforced(t *testing.T) {
+	svc := newOwnershipTestService()
+	const otherUser = 20
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"get ticket", func() error {
+			_, err := svc.GetTicketForUser(1, otherUser, "User")
+			return err
+		}},
+		{"ticket QR", func() error {
+			_, err := svc.GetTicketQR(1, otherUser, "User")
+			return err
+		}},
+		{"seat QR", func() error {
+			_, err := svc.GetTicketItemQR(1, 101, otherUser, "User")
+			return err
+		}},
+		{"update seat holder", func() error {
+			_, err := svc.UpdateTicketItemHolder(1, 101, otherUser, "User", "Mallory", "mallory@example.com")
+			return err
+		}},
+		{"cancel ticket", func() error {
+			_, err := svc.CancelTicket(1, otherUser, "User", "")
+			return err
+		}},
+		{"cancel seat", func() error {
+			_, err := svc.CancelTicketItem(1, 101, otherUser, "User", "")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrTicketForbidden) {
+				t.Fatalf("got error %v, want %v", err, ErrTicketForbidden)
+			}
+		})
+	}
+}
+
+func TestTicketAccessForOwnerAndAdmin(t *testing.T) {
+	svc := newOwnershipTestService()
+
+	for _, actor := range []struct {
+		id   uint
+		role string
+	}{{10, "User"}, {99, "Admin"}} {
+		if _, err := svc.GetTicketForUser(1, actor.id, actor.role); err != nil {
+			t.Errorf("GetTicketForUser as %s %d: %v", actor.role, actor.id, err)
+		}
+		if png, err := svc.GetTicketQR(1, actor.id, actor.role); err != nil || len(png) == 0 {
+			t.Errorf("GetTicketQR as %s %d: %v", actor.role, actor.id, err)
+		}
+	}
+}
+
+func TestMissingTicketIsNotFound(t *testing.T) {
+	svc := newOwnershipTestService()
+
+	if _, err := svc.GetTicketForUser(404, 10, "User"); !errors.Is(err, ErrTicketNotFound) {
+		t.Errorf("GetTicketForUser: got %v, want %v", err, ErrTicketNotFound)
+	}
+	if _, err := svc.CancelTicket(404, 10, "User", ""); !errors.Is(err, ErrTicketNotFound) {
+		t.Errorf("CancelTicket: got %v, want %v", err, ErrTicketNotFound)
+	}
+}
+
+// stubWaitlistService mencatat event yang kursinya ditawarkan ulang ke waitlist
+type stubWaitlistService struct {
+	WaitlistService
+	offered []uint
+}
+
+func (s *stubWaitlistService) OfferReleasedSeats(eventID uint) {
+	s.offered = append(s.offered, eventID)
+}
+
+// Admin boleh membatalkan tiket user lain; refund tetap tercatat untuk pemilik tiket
+func TestAdminCanCancelOtherUsersTicket(t *testing.T) {
+	events := newFakeEventRepo(entity.Event{ID: 1, Status: "active", StartDate: time.Now().Add(30 * 24 * time.Hour), Capacity: 10, SoldCount: 2})
+	tickets := newFakeTicketRepo(entity.Ticket{
+		ID:       1,
+		EventID:  1,
+		UserID:   10,
+		Quantity: 2,
+		Price:    money.New(20000, "IDR"),
+		Status:   "purchased",
+		Items: []entity.TicketItem{
+			{ID: 101, TicketID: 1, EventID: 1, Status: "active"},
+			{ID: 102, TicketID: 1, EventID: 1, Status: "active"},
+		},
+	})
+	refunds := &fakeRefundRepo{}
+	waitlist := &stubWaitlistService{}
+	tx := &fakeTx{repos: repository.Repositories{
+		Events:      events,
+		Tickets:     tickets,
+		TicketItems: &fakeTicketItemRepo{tickets: tickets},
+		EventSeats:  &fakeEventSeatRepo{},
+		Refunds:     refunds,
+	}}
+	payments := NewPaymentService(tx, nil, refunds, nil, time.Hour)
+	svc := NewTicketService(tx, tickets, events, payments, waitlist, ticketcode.NewSigner("test"), OrderPricing{})
+
+	if _, err := svc.GetTicketForUser(1, 99, "Admin"); err != nil {
+		t.Fatalf("admin read: %v", err)
+	}
+	refund, err := svc.CancelTicket(1, 99, "Admin", "requested by support")
+	if err != nil {
+		t.Fatalf("admin cancel: %v", err)
+	}
+
+	if refund.UserID != 10 || refund.Amount != money.New(20000, "IDR") {
+		t.Errorf("refund of %s to user %d, want IDR 20000 to user 10", refund.Amount, refund.UserID)
+	}
+	if got := tickets.tickets[1].Status; got != "cancelled" {
+		t.Errorf("ticket status %q, want cancelled", got)
+	}
+	for _, item := range tickets.tickets[1].Items {
+		if item.Status != "cancelled" {
+			t.Errorf("seat %d status %q, want cancelled", item.ID, item.Status)
+		}
+	}
+	if got := events.events[1].SoldCount; got != 0 {
+		t.Errorf("event still sells %d seats, want 0", got)
+	}
+	if len(waitlist.offered) != 1 || waitlist.offered[0] != 1 {
+		t.Errorf("released seats offered for events %v, want [1]", waitlist.offered)
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/money"
+	"eventix/repository"
+)
+
+type TicketTypeService interface {
+	GetTicketTypesByEventID(eventID uint) ([]entity.TicketType, error)
+	CreateTicketType(ticketType entity.TicketType) (entity.TicketType, error)
+	UpdateTicketType(ticketType entity.TicketType) (entity.TicketType, error)
+	DeleteTicketType(eventID uint, id uint) error
+}
+
+type ticketTypeService struct {
+	txManager repository.TxManager
+	repo      repository.TicketTypeRepository
+	eventRepo repository.EventRepository
+}
+
+func NewTicketTypeService(txManager repository.TxManager, repo repository.TicketTypeRepository, eventRepo repository.EventRepository) TicketTypeService {
+	return &ticketTypeService{
+		txManager: txManager,
+		repo:      repo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *ticketTypeService) GetTicketTypesByEventID(eventID uint) ([]entity.TicketType, error) {
+	if _, err := s.eventRepo.GetEventByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+	return s.repo.GetTicketTypesByEventID(eventID)
+}
+
+func (s *ticketTypeService) CreateTicketType(ticketType entity.TicketType) (entity.TicketType, error) {
+	if err := validateTicketType(ticketType); err != nil {
+		return entity.TicketType{}, err
+	}
+
+	// Penghitung kursi selalu dimulai dari nol
+	ticketType.SoldCount = 0
+	ticketType.ReservedCount = 0
+
+	var created entity.TicketType
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Kunci event agar total kuota kategori tidak melebihi kapasitas saat dibuat paralel
+		event, err := repos.Events.GetEventByIDForUpdate(ticketType.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		if err := matchEventCurrency(&ticketType.Price, event); err != nil {
+			return err
+		}
+		if err := validateTotalQuota(repos.TicketTypes, event, ticketType, 0); err != nil {
+			return err
+		}
+
+		created, err = repos.TicketTypes.CreateTicketType(ticketType)
+		return err
+	})
+	if err != nil {
+		return entity.TicketType{}, err
+	}
+
+	return created, nil
+}
+
+func (s *ticketTypeService) UpdateTicketType(ticketType entity.TicketType) (entity.TicketType, error) {
+	if err := validateTicketType(ticketType); err != nil {
+		return entity.TicketType{}, err
+	}
+
+	var updated entity.TicketType
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		event, err := repos.Events.GetEventByIDForUpdate(ticketType.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		existing, err := repos.TicketTypes.GetTicketTypeByIDForUpdate(ticketType.ID)
+		if err != nil || existing.EventID != ticketType.EventID {
+			return errors.New("ticket type not found")
+		}
+
+		// Validasi kuota tidak boleh lebih kecil dari kursi yang sudah terjual/ditahan
+		if ticketType.Quota < existing.SoldCount+existing.ReservedCount {
+			return errors.New("quota cannot be lower than the number of seats already sold or reserved")
+		}
+
+		if err := matchEventCurrency(&ticketType.Price, event); err != nil {
+			return err
+		}
+		if err := validateTotalQuota(repos.TicketTypes, event, ticketType, existing.ID); err != nil {
+			return err
+		}
+
+		updated, err = repos.TicketTypes.UpdateTicketType(ticketType)
+		return err
+	})
+	if err != nil {
+		return entity.TicketType{}, err
+	}
+
+	return updated, nil
+}
+
+func (s *ticketTypeService) DeleteTicketType(eventID uint, id uint) error {
+	return s.txManager.WithinTx(func(repos repository.Repositories) error {
+		existing, err := repos.TicketTypes.GetTicketTypeByIDForUpdate(id)
+		if err != nil || existing.EventID != eventID {
+			return errors.New("ticket type not found")
+		}
+
+		// Validasi apakah kategori sudah memiliki penjualan
+		if existing.SoldCount > 0 || existing.ReservedCount > 0 {
+			return errors.New("ticket type cannot be deleted because tickets are already sold")
+		}
+
+		return repos.TicketTypes.DeleteTicketType(id)
+	})
+}
+
+// Validasi field kategori tiket
+func validateTicketType(ticketType entity.TicketType) error {
+	if ticketType.Name == "" {
+		return errors.New("ticket type name is required")
+	}
+	if ticketType.Price.IsNegative() {
+		return errors.New("price must be greater than or equal to zero")
+	}
+	if ticketType.Quota <= 0 {
+		return errors.New("quota must be greater than zero")
+	}
+	if ticketType.MaxPerOrder < 0 {
+		return errors.New("max per order must be greater than or equal to zero")
+	}
+	if ticketType.SalesStartAt != nil && ticketType.SalesEndAt != nil && !ticketType.SalesStartAt.Before(*ticketType.SalesEndAt) {
+		return errors.New("sales start must be before sales end")
+	}
+	return nil
+}
+
+// matchEventCurrency menyamakan mata uang harga dengan event; harga tanpa mata uang mengikuti event
+func matchEventCurrency(price *money.Money, event entity.Event) error {
+	if price.Currency == "" {
+		price.Currency = event.Price.Currency
+	}
+	if money.NormalizeCurrency(price.Currency) != event.Price.Currency {
+		return errors.New("price currency must match the event currency")
+	}
+	price.Currency = event.Price.Currency
+	return nil
+}
+
+// Validasi total kuota seluruh kategori tidak melebihi kapasitas event
+func validateTotalQuota(repo repository.TicketTypeRepository, event entity.Event, ticketType entity.TicketType, excludeID uint) error {
+	otherQuota, err := repo.SumQuotaByEventID(event.ID, excludeID)
+	if err != nil {
+		return err
+	}
+	if otherQuota+ticketType.Quota > event.Capacity {
+		return errors.New("total ticket type quota exceeds event capacity")
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/repository"
+	"eventix/ticketcode"
+	"time"
+)
+
+type TransferService interface {
+	RequestTransfer(ticketID uint, fromUserID uint, toUsername string) (entity.TicketTransfer, error)
+	RequestItemTransfer(ticketID uint, itemID uint, fromUserID uint, toUsername string) (entity.TicketTransfer, error)
+	GetPendingTransfers(userID uint) ([]entity.TicketTransfer, error)
+	GetTicketTransfers(ticketID uint, actorID uint, actorRole string) ([]entity.TicketTransfer, error)
+	AcceptTransfer(transferID uint, userID uint) (entity.TicketTransfer, error)
+	DeclineTransfer(transferID uint, userID uint) (entity.TicketTransfer, error)
+	CancelTransfer(transferID uint, userID uint) (entity.TicketTransfer, error)
+}
+
+type transferService struct {
+	txManager  repository.TxManager
+	repo       repository.TicketTransferRepository
+	ticketRepo repository.TicketRepository
+}
+
+func NewTransferService(txManager repository.TxManager, repo repository.TicketTransferRepository, ticketRepo repository.TicketRepository) TransferService {
+	return &transferService{txManager: txManager, repo: repo, ticketRepo: ticketRepo}
+}
+
+// RequestTransfer membuat permintaan transfer yang harus diterima oleh penerima sebelum kepemilikan berpindah
+func (s *transferService) RequestTransfer(ticketID uint, fromUserID uint, toUsername string) (entity.TicketTransfer, error) {
+	return s.requestTransfer(ticketID, nil, fromUserID, toUsername)
+}
+
+// RequestItemTransfer membuat permintaan transfer untuk satu kursi; kursi lain tetap milik pengirim
+func (s *transferService) RequestItemTransfer(ticketID uint, itemID uint, fromUserID uint, toUsername string) (entity.TicketTransfer, error) {
+	return s.requestTransfer(ticketID, &itemID, fromUserID, toUsername)
+}
+
+func (s *transferService) requestTransfer(ticketID uint, itemID *uint, fromUserID uint, toUsername string) (entity.TicketTransfer, error) {
+	if toUsername == "" {
+		return entity.TicketTransfer{}, errors.New("recipient username is required")
+	}
+
+	var transfer entity.TicketTransfer
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		recipient, err := repos.Users.GetUserByUsername(toUsername)
+		if err != nil {
+			return errors.New("recipient not found")
+		}
+		if recipient.ID == fromUserID {
+			return errors.New("cannot transfer a ticket to yourself")
+		}
+
+		ticket, err := repos.Tickets.GetTicketByIDForUpdate(ticketID)
+		if err != nil {
+			return ErrTicketNotFound
+		}
+		if ticket.UserID != fromUserID {
+			return ErrTicketForbidden
+		}
+		if err := checkTransferable(repos, ticket); err != nil {
+			return err
+		}
+		if itemID != nil {
+			item, err := repos.TicketItems.GetItemByIDForUpdate(*itemID)
+			if err != nil || item.TicketID != ticket.ID {
+				return ErrTicketItemNotFound
+			}
+			if err := checkItemTransferable(item); err != nil {
+				return err
+			}
+		}
+
+		// Satu tiket hanya boleh memiliki satu transfer yang menunggu jawaban, baik untuk seluruh tiket maupun satu kursi
+		if _, err := repos.Transfers.GetPendingTransferByTicketID(ticket.ID); err == nil {
+			return ErrTransferPending
+		}
+
+		transfer, err = repos.Transfers.CreateTransfer(entity.TicketTransfer{
+			TicketID:     ticket.ID,
+			TicketItemID: itemID,
+			FromUserID:   fromUserID,
+			ToUserID:     recipient.ID,
+			Status:       "pending",
+		})
+		return err
+	})
+	return transfer, err
+}
+
+// GetPendingTransfers mengambil transfer masuk dan keluar yang belum dijawab
+func (s *transferService) GetPendingTransfers(userID uint) ([]entity.TicketTransfer, error) {
+	return s.repo.GetPendingTransfersForUser(userID)
+}
+
+// GetTicketTransfers mengembalikan riwayat transfer tiket sebagai jejak audit pemilik sebelumnya
+func (s *transferService) GetTicketTransfers(ticketID uint, actorID uint, actorRole string) ([]entity.TicketTransfer, error) {
+	ticket, err := s.ticketRepo.GetTicketByID(ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+	if err := authorizeTicketAccess(ticket, actorID, actorRole); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTransfersByTicketID(ticketID)
+}
+
+// AcceptTransfer memindahkan tiket (atau satu kursinya) ke penerima dan mengganti kodenya agar QR milik pemilik lama tidak berlaku
+func (s *transferService) AcceptTransfer(transferID uint, userID uint) (entity.TicketTransfer, error) {
+	var transfer entity.TicketTransfer
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		var err error
+		transfer, err = lockPendingTransfer(repos, transferID)
+		if err != nil {
+			return err
+		}
+		if transfer.ToUserID != userID {
+			return ErrTransferForbidden
+		}
+
+		// Kunci tiket lalu kursinya (urutan sama dengan pembatalan dan check-in)
+		ticket, err := repos.Tickets.GetTicketByIDForUpdate(transfer.TicketID)
+		if err != nil {
+			return ErrTicketNotFound
+		}
+		if ticket.UserID != transfer.FromUserID {
+			return errors.New("ticket owner has changed since the transfer was requested")
+		}
+		if err := checkTransferable(repos, ticket); err != nil {
+			return err
+		}
+
+		items, err := repos.TicketItems.GetItemsByTicketIDForUpdate(ticket.ID)
+		if err != nil {
+			return err
+		}
+		if transfer.TicketItemID != nil {
+			moved, err := moveTicketItem(repos, ticket, items, *transfer.TicketItemID, userID)
+			if err != nil {
+				return err
+			}
+			// Kursi terakhir yang masih aktif dipindahkan bersama tiketnya
+			if moved != nil {
+				if err := repos.Transfers.UpdateTransferTargetTicket(transfer.ID, moved.ID); err != nil {
+					return err
+				}
+				transfer.ToTicketID = &moved.ID
+				return respondTransfer(repos, &transfer, "accepted")
+			}
+		}
+		for _, item := range items {
+			if item.CheckedInAt != nil {
+				return errors.New("ticket cannot be transferred after check-in")
+			}
+		}
+
+		code, err := ticketcode.NewCode()
+		if err != nil {
+			return err
+		}
+		if err := repos.Tickets.UpdateTicketOwner(ticket.ID, userID, code); err != nil {
+			return err
+		}
+		for _, item := range items {
+			itemCode, err := ticketcode.NewCode()
+			if err != nil {
+				return err
+			}
+			if err := repos.TicketItems.ReassignItem(item.ID, itemCode); err != nil {
+				return err
+			}
+		}
+
+		return respondTransfer(repos, &transfer, "accepted")
+	})
+	return transfer, err
+}
+
+// DeclineTransfer dipakai penerima untuk menolak transfer; tiket tetap milik pengirim
+func (s *transferService) DeclineTransfer(transferID uint, userID uint) (entity.TicketTransfer, error) {
+	var transfer entity.TicketTransfer
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		var err error
+		transfer, err = lockPendingTransfer(repos, transferID)
+		if err != nil {
+			return err
+		}
+		if transfer.ToUserID != userID {
+			return ErrTransferForbidden
+		}
+		return respondTransfer(repos, &transfer, "declined")
+	})
+	return transfer, err
+}
+
+// CancelTransfer dipakai pengirim untuk menarik kembali transfer yang belum dijawab
+func (s *transferService) CancelTransfer(transferID uint, userID uint) (entity.TicketTransfer, error) {
+	var transfer entity.TicketTransfer
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		var err error
+		transfer, err = lockPendingTransfer(repos, transferID)
+		if err != nil {
+			return err
+		}
+		if transfer.FromUserID != userID {
+			return ErrTransferForbidden
+		}
+		return respondTransfer(repos, &transfer, "cancelled")
+	})
+	return transfer, err
+}
+
+// checkTransferable memastikan tiket sudah dibayar dan kebijakan transfer event masih mengizinkan
+func checkTransferable(repos repository.Repositories, ticket entity.Ticket) error {
+	if ticket.Status != "purchased" {
+		return errors.New("only purchased tickets can be transferred")
+	}
+
+	event, err := repos.Events.GetEventByID(ticket.EventID)
+	if err != nil {
+		return errors.New("event not found")
+	}
+	if event.TransferPolicy == "disallowed" {
+		return ErrTransferNotAllowed
+	}
+	if !time.Now().Before(event.TransferDeadline()) {
+		return ErrTransferClosed
+	}
+	return nil
+}
+
+// checkItemTransferable memastikan kursi masih aktif dan belum dipakai masuk
+func checkItemTransferable(item entity.TicketItem) error {
+	if item.Status != "active" {
+		return errors.New("only active seats can be transferred")
+	}
+	if item.CheckedInAt != nil {
+		return errors.New("ticket cannot be transferred after check-in")
+	}
+	return nil
+}
+
+// moveTicketItem memecah satu kursi dari tiket menjadi tiket baru milik penerima dengan kode baru.
+// Tiket baru membawa bagian harga dan diskon kursi itu dari order yang sama sehingga refund-nya tetap dihitung
+// dari tagihan order; bagiannya diambil dari ujung agar bagian kursi yang sudah di-refund tidak berubah.
+// Jika kursi itu satu-satunya yang masih aktif, tidak ada yang dipecah dan nil dikembalikan agar seluruh tiket dipindahkan.
+func moveTicketItem(repos repository.Repositories, ticket entity.Ticket, items []entity.TicketItem, itemID uint, userID uint) (*entity.Ticket, error) {
+	var target *entity.TicketItem
+	active := 0
+	for i := range items {
+		if items[i].ID == itemID {
+			target = &items[i]
+		}
+		if items[i].Status == "active" {
+			active++
+		}
+	}
+	if target == nil {
+		return nil, ErrTicketItemNotFound
+	}
+	if err := checkItemTransferable(*target); err != nil {
+		return nil, err
+	}
+	if active == 1 {
+		return nil, nil
+	}
+
+	q := ticket.Quantity
+	keepPrice := ticket.Price.Share(q-1, q)
+	keepDiscount := ticket.Discount.Share(q-1, q)
+
+	ticketCode, err := ticketcode.NewCode()
+	if err != nil {
+		return nil, err
+	}
+	moved, err := repos.Tickets.CreateTicket(entity.Ticket{
+		EventID:         ticket.EventID,
+		TicketTypeID:    ticket.TicketTypeID,
+		UserID:          userID,
+		OrderID:         ticket.OrderID,
+		Code:            ticketCode,
+		Quantity:        1,
+		Price:           ticket.Price.Sub(keepPrice),
+		Discount:        ticket.Discount.Sub(keepDiscount),
+		PromoCodeID:     ticket.PromoCodeID,
+		PricingRuleID:   ticket.PricingRuleID,
+		Status:          ticket.Status,
+		PaymentIntentID: ticket.PaymentIntentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := repos.Tickets.UpdateTicketQuantity(ticket.ID, q-1, keepPrice, keepDiscount); err != nil {
+		return nil, err
+	}
+
+	itemCode, err := ticketcode.NewCode()
+	if err != nil {
+		return nil, err
+	}
+	if err := repos.TicketItems.MoveItem(target.ID, moved.ID, itemCode); err != nil {
+		return nil, err
+	}
+	if err := repos.EventSeats.MoveSeatToTicket(target.ID, moved.ID); err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+func lockPendingTransfer(repos repository.Repositories, transferID uint) (entity.TicketTransfer, error) {
+	transfer, err := repos.Transfers.GetTransferByIDForUpdate(transferID)
+	if err != nil {
+		return entity.TicketTransfer{}, ErrTransferNotFound
+	}
+	if transfer.Status != "pending" {
+		return entity.TicketTransfer{}, errors.New("transfer has already been " + transfer.Status)
+	}
+	return transfer, nil
+}
+
+func respondTransfer(repos repository.Repositories, transfer *entity.TicketTransfer, status string) error {
+	now := time.Now()
+	if err := repos.Transfers.UpdateTransferStatus(transfer.ID, status, now); err != nil {
+		return err
+	}
+	transfer.Status = status
+	transfer.RespondedAt = &now
+	return nil
+}
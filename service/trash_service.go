@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/repository"
+	"time"
+)
+
+type TrashService interface {
+	GetDeletedEvents(page int, size int) ([]entity.Event, int64, error)
+	RestoreEvent(id uint) error
+	DeleteTicket(id uint) error
+	GetDeletedTickets(page int, size int) ([]entity.Ticket, int64, error)
+	RestoreTicket(id uint) error
+	DeleteUser(id uint, actorID uint) error
+	GetDeletedUsers(page int, size int) ([]entity.User, int64, error)
+	RestoreUser(id uint) error
+	PurgeExpired() (int64, error)
+}
+
+type trashService struct {
+	txManager  repository.TxManager
+	eventRepo  repository.EventRepository
+	ticketRepo repository.TicketRepository
+	userRepo   repository.UserRepository
+	retention  time.Duration
+}
+
+func NewTrashService(txManager repository.TxManager, eventRepo repository.EventRepository, ticketRepo repository.TicketRepository, userRepo repository.UserRepository, retention time.Duration) TrashService {
+	return &trashService{
+		txManager:  txManager,
+		eventRepo:  eventRepo,
+		ticketRepo: ticketRepo,
+		userRepo:   userRepo,
+		retention:  retention,
+	}
+}
+
+func (s *trashService) GetDeletedEvents(page int, size int) ([]entity.Event, int64, error) {
+	return s.eventRepo.GetDeletedEvents(page, size)
+}
+
+// RestoreEvent memulihkan event terhapus selama namanya belum dipakai event atau seri lain
+func (s *trashService) RestoreEvent(id uint) error {
+	event, err := s.eventRepo.GetDeletedEventByID(id)
+	if err != nil {
+		return errors.New("deleted event not found")
+	}
+
+	isUnique, err := s.eventRepo.IsEventNameUnique(event.Name, event.ID, event.SeriesID)
+	if err != nil {
+		return err
+	}
+	if !isUnique {
+		return errors.New("event name is already used by another event")
+	}
+
+	return s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Kemunculan seri yang dipulihkan tidak lagi dikecualikan dari seri
+		if event.SeriesID != nil && event.OccurrenceStart != nil {
+			if err := repos.Series.DeleteExceptionByStart(*event.SeriesID, *event.OccurrenceStart); err != nil {
+				return err
+			}
+		}
+		return repos.Events.RestoreEvent(id)
+	})
+}
+
+// DeleteTicket hanya mengizinkan tiket yang sudah tidak berlaku agar penghitung kursi event tetap konsisten
+func (s *trashService) DeleteTicket(id uint) error {
+	return s.txManager.WithinTx(func(repos repository.Repositories) error {
+		ticket, err := repos.Tickets.GetTicketByIDForUpdate(id)
+		if err != nil {
+			return ErrTicketNotFound
+		}
+		if ticket.Status != "cancelled" && ticket.Status != "payment_failed" {
+			return errors.New("only cancelled or failed tickets can be deleted")
+		}
+		return repos.Tickets.DeleteTicket(ticket.ID)
+	})
+}
+
+func (s *trashService) GetDeletedTickets(page int, size int) ([]entity.Ticket, int64, error) {
+	return s.ticketRepo.GetDeletedTickets(page, size)
+}
+
+// RestoreTicket memulihkan tiket terhapus; event-nya harus masih ada
+func (s *trashService) RestoreTicket(id uint) error {
+	ticket, err := s.ticketRepo.GetDeletedTicketByID(id)
+	if err != nil {
+		return errors.New("deleted ticket not found")
+	}
+	if _, err := s.eventRepo.GetEventByID(ticket.EventID); err != nil {
+		return errors.New("restore the event before restoring its tickets")
+	}
+	return s.ticketRepo.RestoreTicket(id)
+}
+
+func (s *trashService) DeleteUser(id uint, actorID uint) error {
+	if id == actorID {
+		return errors.New("you cannot delete your own account")
+	}
+	if err := s.userRepo.DeleteUser(id); err != nil {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+func (s *trashService) GetDeletedUsers(page int, size int) ([]entity.User, int64, error) {
+	return s.userRepo.GetDeletedUsers(page, size)
+}
+
+func (s *trashService) RestoreUser(id uint) error {
+	if err := s.userRepo.RestoreUser(id); err != nil {
+		return errors.New("deleted user not found")
+	}
+	return nil
+}
+
+// PurgeExpired menghapus permanen data yang sudah di-soft delete lebih lama dari masa retensi.
+// Tiket dibersihkan lebih dulu agar event dan user yang dirujuknya ikut bisa dibersihkan.
+func (s *trashService) PurgeExpired() (int64, error) {
+	before := time.Now().Add(-s.retention)
+
+	var purged int64
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		tickets, err := repos.Tickets.PurgeDeletedTickets(before)
+		if err != nil {
+			return err
+		}
+		events, err := repos.Events.PurgeDeletedEvents(before)
+		if err != nil {
+			return err
+		}
+		users, err := repos.Users.PurgeDeletedUsers(before)
+		if err != nil {
+			return err
+		}
+		purged = tickets + events + users
+		return nil
+	})
+	return purged, err
+}
@@ -46,7 +46,7 @@ func (s *userService) GetUserByID(id uint) (entity.User, error) {
 
 func (s *userService) UpdateUserRole(userID uint, role string) error {
     // Validasi role (opsional, pastikan hanya role valid yang diterima)
-    validRoles := []string{"Admin", "User"}
+    validRoles := []string{"Admin", "User", "Staff"}
     isValid := false
     for _, r := range validRoles {
         if r == role {
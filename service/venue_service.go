@@ -0,0 +1,252 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"eventix/entity"
+	"eventix/repository"
+	"io"
+	"strings"
+)
+
+type VenueService interface {
+	GetAllVenues() ([]entity.Venue, error)
+	GetVenueSeatMap(id uint) (entity.Venue, error)
+	CreateVenue(venue entity.Venue) (entity.Venue, error)
+	ImportSeatMap(venueID uint, format string, data []byte) (entity.Venue, error)
+	GetEventSeats(eventID uint) (entity.Venue, error)
+}
+
+type venueService struct {
+	txManager repository.TxManager
+	repo      repository.VenueRepository
+	eventRepo repository.EventRepository
+	seatRepo  repository.EventSeatRepository
+}
+
+func NewVenueService(txManager repository.TxManager, repo repository.VenueRepository, eventRepo repository.EventRepository, seatRepo repository.EventSeatRepository) VenueService {
+	return &venueService{
+		txManager: txManager,
+		repo:      repo,
+		eventRepo: eventRepo,
+		seatRepo:  seatRepo,
+	}
+}
+
+// seatMapFile adalah format JSON untuk impor denah kursi
+type seatMapFile struct {
+	Sections []struct {
+		Name string `json:"name"`
+		Rows []struct {
+			Label string   `json:"label"`
+			Seats []string `json:"seats"`
+		} `json:"rows"`
+	} `json:"sections"`
+}
+
+func (s *venueService) GetAllVenues() ([]entity.Venue, error) {
+	return s.repo.GetAllVenues()
+}
+
+func (s *venueService) GetVenueSeatMap(id uint) (entity.Venue, error) {
+	venue, err := s.repo.GetVenueWithSeatMap(id)
+	if err != nil {
+		return entity.Venue{}, errors.New("venue not found")
+	}
+	return venue, nil
+}
+
+func (s *venueService) CreateVenue(venue entity.Venue) (entity.Venue, error) {
+	if strings.TrimSpace(venue.Name) == "" {
+		return entity.Venue{}, errors.New("venue name is required")
+	}
+	venue.ID = 0
+	venue.Sections = nil
+	return s.repo.CreateVenue(venue)
+}
+
+// ImportSeatMap mengganti denah kursi venue dari file JSON atau CSV (kolom: section,row,seat)
+func (s *venueService) ImportSeatMap(venueID uint, format string, data []byte) (entity.Venue, error) {
+	var sections []entity.Section
+	var err error
+	switch format {
+	case "json":
+		sections, err = parseSeatMapJSON(data)
+	case "csv":
+		sections, err = parseSeatMapCSV(data)
+	default:
+		return entity.Venue{}, errors.New("seat map format must be json or csv")
+	}
+	if err != nil {
+		return entity.Venue{}, err
+	}
+	if len(sections) == 0 {
+		return entity.Venue{}, errors.New("seat map has no seats")
+	}
+
+	err = s.txManager.WithinTx(func(repos repository.Repositories) error {
+		if _, err := repos.Venues.GetVenueByID(venueID); err != nil {
+			return errors.New("venue not found")
+		}
+
+		// Denah tidak boleh diganti jika kursinya sudah terjual, karena ID kursi lama akan hilang
+		inUse, err := repos.Venues.CountSeatsInUse(venueID)
+		if err != nil {
+			return err
+		}
+		if inUse > 0 {
+			return errors.New("seat map cannot be replaced because seats have already been sold")
+		}
+
+		return repos.Venues.ReplaceSeatMap(venueID, sections)
+	})
+	if err != nil {
+		return entity.Venue{}, err
+	}
+
+	return s.repo.GetVenueWithSeatMap(venueID)
+}
+
+// GetEventSeats mengembalikan denah venue event dengan status available/taken untuk setiap kursi
+func (s *venueService) GetEventSeats(eventID uint) (entity.Venue, error) {
+	event, err := s.eventRepo.GetEventByID(eventID)
+	if err != nil || event.Status == "draft" {
+		return entity.Venue{}, errors.New("event not found")
+	}
+	if event.VenueID == nil {
+		return entity.Venue{}, errors.New("this event does not use assigned seating")
+	}
+
+	venue, err := s.repo.GetVenueWithSeatMap(*event.VenueID)
+	if err != nil {
+		return entity.Venue{}, errors.New("venue not found")
+	}
+
+	takenIDs, err := s.seatRepo.GetTakenSeatIDs(event.ID)
+	if err != nil {
+		return entity.Venue{}, err
+	}
+	taken := make(map[uint]bool, len(takenIDs))
+	for _, id := range takenIDs {
+		taken[id] = true
+	}
+
+	for i := range venue.Sections {
+		for j := range venue.Sections[i].Rows {
+			seats := venue.Sections[i].Rows[j].Seats
+			for k := range seats {
+				seats[k].Status = "available"
+				if taken[seats[k].ID] {
+					seats[k].Status = "taken"
+				}
+			}
+		}
+	}
+	return venue, nil
+}
+
+func parseSeatMapJSON(data []byte) ([]entity.Section, error) {
+	var file seatMapFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.New("invalid seat map JSON")
+	}
+
+	var sections []entity.Section
+	for _, fileSection := range file.Sections {
+		section := entity.Section{Name: strings.TrimSpace(fileSection.Name)}
+		if section.Name == "" {
+			return nil, errors.New("section name is required")
+		}
+		for _, fileRow := range fileSection.Rows {
+			row := entity.SeatRow{Label: strings.TrimSpace(fileRow.Label)}
+			if row.Label == "" {
+				return nil, errors.New("row label is required")
+			}
+			for _, number := range fileRow.Seats {
+				if number = strings.TrimSpace(number); number == "" {
+					return nil, errors.New("seat number is required")
+				}
+				row.Seats = append(row.Seats, entity.Seat{Number: number})
+			}
+			section.Rows = append(section.Rows, row)
+		}
+		sections = append(sections, section)
+	}
+	return sections, validateSeatMap(sections)
+}
+
+func parseSeatMapCSV(data []byte) ([]entity.Section, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var sections []entity.Section
+	sectionIndex := map[string]int{}
+	rowIndex := map[string]int{}
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("invalid seat map CSV: each line must contain section,row,seat")
+		}
+		line++
+
+		sectionName, rowLabel, number := strings.TrimSpace(record[0]), strings.TrimSpace(record[1]), strings.TrimSpace(record[2])
+		// Baris header boleh ada atau tidak
+		if line == 1 && strings.EqualFold(sectionName, "section") {
+			continue
+		}
+		if sectionName == "" || rowLabel == "" || number == "" {
+			return nil, errors.New("invalid seat map CSV: section, row and seat are required")
+		}
+
+		si, ok := sectionIndex[sectionName]
+		if !ok {
+			si = len(sections)
+			sectionIndex[sectionName] = si
+			sections = append(sections, entity.Section{Name: sectionName})
+		}
+		rowKey := sectionName + "\x00" + rowLabel
+		ri, ok := rowIndex[rowKey]
+		if !ok {
+			ri = len(sections[si].Rows)
+			rowIndex[rowKey] = ri
+			sections[si].Rows = append(sections[si].Rows, entity.SeatRow{Label: rowLabel})
+		}
+		sections[si].Rows[ri].Seats = append(sections[si].Rows[ri].Seats, entity.Seat{Number: number})
+	}
+	return sections, validateSeatMap(sections)
+}
+
+// validateSeatMap menolak section, row atau nomor kursi yang ganda
+func validateSeatMap(sections []entity.Section) error {
+	sectionNames := map[string]bool{}
+	for _, section := range sections {
+		if sectionNames[section.Name] {
+			return errors.New("duplicate section: " + section.Name)
+		}
+		sectionNames[section.Name] = true
+
+		rowLabels := map[string]bool{}
+		for _, row := range section.Rows {
+			if rowLabels[row.Label] {
+				return errors.New("duplicate row " + row.Label + " in section " + section.Name)
+			}
+			rowLabels[row.Label] = true
+
+			numbers := map[string]bool{}
+			for _, seat := range row.Seats {
+				if numbers[seat.Number] {
+					return errors.New("duplicate seat " + seat.Number + " in row " + row.Label + " of section " + section.Name)
+				}
+				numbers[seat.Number] = true
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"errors"
+	"eventix/entity"
+	"eventix/repository"
+	"log"
+	"time"
+)
+
+type WaitlistService interface {
+	JoinWaitlist(entry entity.WaitlistEntry) (entity.WaitlistEntry, error)
+	GetWaitlistEntry(eventID uint, userID uint) (entity.WaitlistEntry, error)
+	LeaveWaitlist(eventID uint, userID uint) error
+	PromoteWaitlist(eventID uint) (int, error)
+	OfferReleasedSeats(eventID uint)
+	PromoteAllWaitlists() (int, error)
+}
+
+type waitlistService struct {
+	txManager repository.TxManager
+	repo      repository.WaitlistRepository
+	offerTTL  time.Duration
+}
+
+func NewWaitlistService(txManager repository.TxManager, repo repository.WaitlistRepository, offerTTL time.Duration) WaitlistService {
+	return &waitlistService{
+		txManager: txManager,
+		repo:      repo,
+		offerTTL:  offerTTL,
+	}
+}
+
+// JoinWaitlist memasukkan user ke antrean event yang kursinya tidak lagi mencukupi
+func (s *waitlistService) JoinWaitlist(entry entity.WaitlistEntry) (entity.WaitlistEntry, error) {
+	var created entity.WaitlistEntry
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Kunci event agar pengecekan ketersediaan dan antrean konsisten dengan promosi
+		event, err := repos.Events.GetEventByIDForUpdate(entry.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+		// Antrean hanya dibuka selama penjualan berlangsung
+		if err := checkSalesWindow(event, time.Now()); err != nil {
+			return err
+		}
+
+		// Antrean tidak boleh meminta lebih dari yang boleh dibeli user
+		if err := checkPurchaseLimits(repos, event, entry.UserID, entry.Quantity); err != nil {
+			return err
+		}
+
+		if _, err := repos.Waitlist.GetActiveEntryForUpdate(entry.EventID, entry.UserID); err == nil {
+			return errors.New("you are already on the waitlist for this event")
+		}
+
+		// Antrean hanya untuk event yang habis; jika kursi masih ada user harus langsung membeli
+		if entry.Quantity <= event.AvailableCapacity() {
+			if _, err := resolveUnitPrice(repos, event, entry.TicketTypeID, entry.Quantity); err == nil {
+				return errors.New("seats are still available for this event")
+			}
+		}
+
+		entry.ID = 0
+		entry.Status = "waiting"
+		entry.ReservationID = nil
+		entry.OfferedAt = nil
+		created, err = repos.Waitlist.CreateEntry(entry)
+		return err
+	})
+	if err != nil {
+		return entity.WaitlistEntry{}, err
+	}
+
+	return s.withPosition(created)
+}
+
+// GetWaitlistEntry mengambil antrean aktif user beserta posisinya
+func (s *waitlistService) GetWaitlistEntry(eventID uint, userID uint) (entity.WaitlistEntry, error) {
+	entry, err := s.repo.GetActiveEntry(eventID, userID)
+	if err != nil {
+		return entity.WaitlistEntry{}, errors.New("you are not on the waitlist for this event")
+	}
+	return s.withPosition(entry)
+}
+
+// LeaveWaitlist mengeluarkan user dari antrean; kursi yang sedang ditawarkan dikembalikan ke antrean berikutnya
+func (s *waitlistService) LeaveWaitlist(eventID uint, userID uint) error {
+	released := false
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		entry, err := repos.Waitlist.GetActiveEntryForUpdate(eventID, userID)
+		if err != nil {
+			return errors.New("you are not on the waitlist for this event")
+		}
+
+		if entry.Status == "offered" && entry.ReservationID != nil {
+			reservation, err := repos.Reservations.GetReservationByIDForUpdate(*entry.ReservationID)
+			if err != nil {
+				return err
+			}
+			if reservation.Status == "held" {
+				if err := releaseReservation(repos, reservation, "expired"); err != nil {
+					return err
+				}
+				released = true
+			}
+		}
+
+		return repos.Waitlist.UpdateEntryStatus(entry.ID, "left")
+	})
+	if err != nil {
+		return err
+	}
+
+	if released {
+		s.OfferReleasedSeats(eventID)
+	}
+	return nil
+}
+
+// OfferReleasedSeats langsung menawarkan kursi yang baru dilepas ke waitlist setelah aksi user di-commit.
+// Kegagalan hanya dicatat dan tidak membatalkan aksi user; job waitlist-promoter akan mengulang promosinya.
+func (s *waitlistService) OfferReleasedSeats(eventID uint) {
+	if _, err := s.PromoteWaitlist(eventID); err != nil {
+		log.Printf("Failed to promote waitlist for event %d: %v\n", eventID, err)
+	}
+}
+
+// PromoteWaitlist menawarkan reservasi berbatas waktu kepada antrean terdepan selama kursi mencukupi
+func (s *waitlistService) PromoteWaitlist(eventID uint) (int, error) {
+	promoted := 0
+	err := s.txManager.WithinTx(func(repos repository.Repositories) error {
+		// Urutan lock sama dengan pembelian: event, antrean, lalu kategori tiket
+		event, err := repos.Events.GetEventByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+		// Tawaran baru tidak dibuat setelah penjualan ditutup
+		if event.SalesStateAt(time.Now()) != "on_sale" {
+			return nil
+		}
+
+		entries, err := repos.Waitlist.GetWaitingEntriesForUpdate(eventID, 50)
+		if err != nil {
+			return err
+		}
+
+		available := event.AvailableCapacity()
+		now := time.Now()
+		for _, entry := range entries {
+			// FIFO: berhenti pada antrean terdepan yang belum tertampung kapasitas event
+			if entry.Quantity > available {
+				break
+			}
+			// Kuota kategori yang belum cukup tidak menghalangi antrean untuk kategori lain
+			if _, err := resolveUnitPrice(repos, event, entry.TicketTypeID, entry.Quantity); err != nil {
+				continue
+			}
+
+			if err := repos.Events.AddReservedCount(event.ID, entry.Quantity); err != nil {
+				return errors.New("failed to update event capacity")
+			}
+			if entry.TicketTypeID != nil {
+				if err := repos.TicketTypes.AddReservedCount(*entry.TicketTypeID, entry.Quantity); err != nil {
+					return errors.New("failed to update ticket type quota")
+				}
+			}
+
+			reservation, err := repos.Reservations.CreateReservation(entity.Reservation{
+				EventID:      entry.EventID,
+				TicketTypeID: entry.TicketTypeID,
+				UserID:       entry.UserID,
+				Quantity:     entry.Quantity,
+				Status:       "held",
+				ExpiresAt:    now.Add(s.offerTTL),
+			})
+			if err != nil {
+				return err
+			}
+			if err := repos.Waitlist.MarkEntryOffered(entry.ID, reservation.ID, now); err != nil {
+				return err
+			}
+
+			available -= entry.Quantity
+			promoted++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return promoted, nil
+}
+
+// PromoteAllWaitlists dijalankan scheduler untuk menampung kursi yang dilepas pembayaran gagal atau reservasi kedaluwarsa
+func (s *waitlistService) PromoteAllWaitlists() (int, error) {
+	eventIDs, err := s.repo.GetEventIDsWithWaitingEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, eventID := range eventIDs {
+		promoted, err := s.PromoteWaitlist(eventID)
+		if err != nil {
+			return total, err
+		}
+		total += promoted
+	}
+	return total, nil
+}
+
+func (s *waitlistService) withPosition(entry entity.WaitlistEntry) (entity.WaitlistEntry, error) {
+	if entry.Status != "waiting" {
+		return entry, nil
+	}
+	ahead, err := s.repo.CountWaitingAhead(entry.EventID, entry.ID)
+	if err != nil {
+		return entity.WaitlistEntry{}, err
+	}
+	entry.Position = ahead + 1
+	return entry, nil
+}
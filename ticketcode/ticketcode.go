@@ -0,0 +1,73 @@
+// Package ticketcode membuat kode tiket acak dan payload bertanda tangan HMAC untuk QR code.
+package ticketcode
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const payloadPrefix = "EVX1"
+
+var ErrInvalidPayload = errors.New("invalid ticket payload")
+
+// Alfabet tanpa karakter yang mudah tertukar (0/O, 1/I) agar bisa dibaca petugas
+var codeEncoding = base32.NewEncoding("ABCDEFGHJKLMNPQRSTUVWXYZ23456789").WithPadding(base32.NoPadding)
+
+// NewCode membuat kode tiket acak 16 karakter (80 bit)
+func NewCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return codeEncoding.EncodeToString(b), nil
+}
+
+// Signer menandatangani dan memverifikasi payload tiket dengan HMAC-SHA256
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key string) *Signer {
+	return &Signer{key: []byte(key)}
+}
+
+// Payload menghasilkan string "EVX1.<event_id>.<code>.<signature>" untuk dirender sebagai QR code
+func (s *Signer) Payload(eventID uint, code string) string {
+	message := fmt.Sprintf("%s.%d.%s", payloadPrefix, eventID, code)
+	return message + "." + s.sign(message)
+}
+
+// Verify memeriksa tanda tangan payload dan mengembalikan event ID serta kode tiket di dalamnya
+func (s *Signer) Verify(payload string) (uint, string, error) {
+	idx := strings.LastIndex(payload, ".")
+	if idx < 0 {
+		return 0, "", ErrInvalidPayload
+	}
+	message, signature := payload[:idx], payload[idx+1:]
+	if !hmac.Equal([]byte(s.sign(message)), []byte(signature)) {
+		return 0, "", ErrInvalidPayload
+	}
+
+	parts := strings.Split(message, ".")
+	if len(parts) != 3 || parts[0] != payloadPrefix {
+		return 0, "", ErrInvalidPayload
+	}
+	eventID, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, "", ErrInvalidPayload
+	}
+	return uint(eventID), parts[2], nil
+}
+
+func (s *Signer) sign(message string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}